@@ -18,6 +18,7 @@
 package accounts
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -46,6 +47,11 @@ type BalanceQueryResponse struct {
 
 // BalanceQuery queries the merchant account balance.
 func (s *Service) BalanceQuery(req *BalanceQueryRequest) (*BalanceQueryResponse, error) {
+	return s.BalanceQueryContext(context.Background(), req)
+}
+
+// BalanceQueryContext is like BalanceQuery but carries the context for cancellation and deadlines.
+func (s *Service) BalanceQueryContext(ctx context.Context, req *BalanceQueryRequest) (*BalanceQueryResponse, error) {
 	// Validate request
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
@@ -55,7 +61,7 @@ func (s *Service) BalanceQuery(req *BalanceQueryRequest) (*BalanceQueryResponse,
 	}
 
 	// Call API with function code 6003
-	respData, err := s.client.Do(cores.FunCodeBalanceQuery, req)
+	respData, err := s.client.DoContext(ctx, cores.FunCodeBalanceQuery, req)
 	if err != nil {
 		return nil, err
 	}
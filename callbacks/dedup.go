@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbacks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/freelancers"
+	"github.com/vogo/vservicesharesdk/payments"
+	"github.com/vogo/vservicesharesdk/recharges"
+)
+
+const (
+	DefaultDedupTTL          = 24 * time.Hour   // default time a processed key is remembered
+	DefaultProcessingTimeout = 15 * time.Minute // default time a processing claim is held, well above the 2 minute platform resend interval
+)
+
+var (
+	// ErrProcessing is returned when another caller is processing the same notification.
+	ErrProcessing = errors.New("notification is being processed")
+	// ErrClaimLost is returned by Complete when the claim expired and was acquired by another caller.
+	ErrClaimLost = errors.New("dedup claim expired and was taken over")
+)
+
+// KeyState represents the processing state of a dedup key.
+type KeyState int
+
+const (
+	KeyAcquired   KeyState = iota // the key is claimed for processing by the caller
+	KeyProcessing                 // the key is claimed by another caller
+	KeyCompleted                  // the key has been processed
+)
+
+// Store records the processing of notification keys.
+//
+// IMPORTANT NOTES:
+// - Acquire must be atomic, so only one of concurrent callers gets KeyAcquired.
+// - A claim not completed or released within the processing timeout may be acquired again.
+// - Each claim has its own token, so a caller whose claim was taken over cannot complete or release the new claim.
+type Store interface {
+	// Acquire claims the key for processing, returning the claim token if the state is KeyAcquired.
+	Acquire(ctx context.Context, key string) (KeyState, string, error)
+	// Complete marks the key claimed with the token as processed, returning ErrClaimLost if the claim was taken over.
+	Complete(ctx context.Context, key, token string) error
+	// Release gives up the claim of the token, letting a resent notification process the key again.
+	Release(ctx context.Context, key, token string) error
+}
+
+// Once runs fn at most once per key.
+//
+// It returns nil without running fn if the key has been processed, and ErrProcessing if
+// another caller is processing it. The claim is released if fn fails.
+func Once(ctx context.Context, store Store, key string, fn func(ctx context.Context) error) error {
+	state, token, err := store.Acquire(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to acquire %s: %w", key, err)
+	}
+
+	switch state {
+	case KeyCompleted:
+		return nil
+	case KeyProcessing:
+		return fmt.Errorf("%w: %s", ErrProcessing, key)
+	}
+
+	// Record the outcome even if the request was cancelled while fn ran
+	storeCtx := context.WithoutCancel(ctx)
+
+	if err := fn(ctx); err != nil {
+		if releaseErr := store.Release(storeCtx, key, token); releaseErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to release %s: %w", key, releaseErr))
+		}
+		return err
+	}
+
+	if err := store.Complete(storeCtx, key, token); err != nil {
+		return fmt.Errorf("failed to complete %s: %w", key, err)
+	}
+	return nil
+}
+
+// newClaimToken returns a random claim token.
+func newClaimToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate claim token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// PaymentKey returns the dedup key of a payment notification.
+func PaymentKey(result *payments.PaymentResult) string {
+	return fmt.Sprintf("payment:%d:%d", result.OrderNo, result.State)
+}
+
+// SignKey returns the dedup key of a contract signing notification.
+//
+// The ID card number is hashed with the provider ID, so it is neither stored in the dedup
+// table nor logged with dedup errors.
+func SignKey(result *freelancers.SignContractResult) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d", result.IdCard, result.ProviderId)))
+	return fmt.Sprintf("sign:%s:%d", hex.EncodeToString(sum[:]), result.State)
+}
+
+// RechargeKey returns the dedup key of a recharge notification.
+func RechargeKey(result *recharges.RechargeCallbackResult) string {
+	return "recharge:" + result.OrderNo
+}
+
+// MerchantRechargeKey returns the dedup key of a merchant portal recharge notification.
+// It equals the RechargeKey of the same recharge, so a recharge notified to both callback URLs is processed once.
+func MerchantRechargeKey(result *recharges.MerchantRechargeCallbackResult) string {
+	return "recharge:" + result.OrderNo
+}
@@ -0,0 +1,323 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbacks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/freelancers"
+	"github.com/vogo/vservicesharesdk/payments"
+	"github.com/vogo/vservicesharesdk/recharges"
+)
+
+// DefaultMaxBodySize is the default limit of the notification request body in bytes.
+const DefaultMaxBodySize = 1 << 20
+
+// notificationFail is the reply making the platform resend the notification.
+const notificationFail = "FAIL"
+
+// Notification represents a verified and decrypted platform notification.
+type Notification struct {
+	FunCode string // the function code of the notification envelope
+	Data    string // the decrypted notification data in JSON
+}
+
+// Handler is an http.Handler receiving platform notifications.
+//
+// It verifies and decrypts each notification, dispatches it by the function code of its
+// envelope to the typed hook, and replies SUCCESS only when the hook returns nil.
+// Otherwise the platform resends the notification every 2 minutes, up to 5 times.
+//
+// The API document samples only the envelope of the payment notification (6001, §5.3.4).
+// The other typed hooks assume the envelope carries the function code of the request that
+// registered the notifyUrl: one-click payment 6029 (§5.16.3), contract signing 6010 and 6026
+// (§5.1.4) and recharge 6020 (§5.9.4). The merchant portal recharge notification (§5.19) is not
+// requested by any call and its function code is not documented, so OnMerchantRecharge receives
+// every notification without a typed hook for its function code; mount a handler with only
+// OnMerchantRecharge set at the callback URL configured in the merchant portal.
+// Notifications left without a hook are passed to OnRaw, and are not acknowledged without it.
+//
+// With a Store set, each typed hook runs once per PaymentKey, SignKey, RechargeKey or MerchantRechargeKey.
+// Resent notifications already processed are acknowledged without running the hook again,
+// and ones arriving while the first is being processed are not acknowledged, so they are resent later.
+type Handler struct {
+	client *cores.Client
+
+	OnPayment  func(ctx context.Context, result *payments.PaymentResult) error           // handles batch and one-click payment notifications (6001, 6029)
+	OnSign     func(ctx context.Context, result *freelancers.SignContractResult) error   // handles contract signing notifications (6010, 6026)
+	OnRecharge func(ctx context.Context, result *recharges.RechargeCallbackResult) error // handles recharge notifications (6020)
+
+	OnMerchantRecharge func(ctx context.Context, result *recharges.MerchantRechargeCallbackResult) error // handles merchant portal recharge notifications (§5.19) of any function code without a typed hook
+	OnRaw              func(ctx context.Context, notification *Notification) error                       // handles notifications without any other hook
+
+	Store          Store          // the dedup store of typed hooks, no dedup if nil
+	MaxBodySize    int64          // the limit of the request body in bytes (default: DefaultMaxBodySize)
+	AllowedIPs     []netip.Prefix // the source IPs allowed to notify, all if empty
+	RemoteIPHeader string         // the header carrying the source IP behind a proxy, e.g. X-Forwarded-For; RemoteAddr if empty
+	TrustedProxies []netip.Prefix // the proxies whose RemoteIPHeader is honoured, the header is ignored if empty
+}
+
+// NewHandler creates a new notification handler.
+func NewHandler(client *cores.Client) *Handler {
+	return &Handler{
+		client:      client,
+		MaxBodySize: DefaultMaxBodySize,
+	}
+}
+
+// ParseAllowedIPs parses IP addresses and CIDR prefixes for Handler.AllowedIPs and Handler.TrustedProxies.
+func ParseAllowedIPs(ips ...string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(ips))
+	for _, ip := range ips {
+		if strings.Contains(ip, "/") {
+			prefix, err := netip.ParsePrefix(ip)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowed ip %s: %w", ip, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed ip %s: %w", ip, err)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.client.Logger()
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.allowed(r) {
+		logger.LogAttrs(ctx, slog.LevelWarn, "service share notification from disallowed ip",
+			slog.String("remoteAddr", r.RemoteAddr),
+		)
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	// Read request body within the limit
+	maxBodySize := h.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	notification, err := h.parse(body)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "service share notification invalid",
+			slog.Any("err", err),
+		)
+		http.Error(w, notificationFail, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.dispatch(ctx, notification); err != nil {
+		// A concurrent resend is expected, not a failure
+		level := slog.LevelError
+		if errors.Is(err, ErrProcessing) {
+			level = slog.LevelInfo
+		}
+		logger.LogAttrs(ctx, level, "service share notification not handled",
+			slog.String("funCode", notification.FunCode),
+			slog.Any("err", err),
+		)
+		http.Error(w, notificationFail, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain;charset=utf-8")
+	_, _ = w.Write(cores.NotificationAckBody())
+}
+
+// allowed reports whether the source IP of the request is allowed.
+func (h *Handler) allowed(r *http.Request) bool {
+	if len(h.AllowedIPs) == 0 {
+		return true
+	}
+
+	addr, ok := h.remoteIP(r)
+	return ok && containsIP(h.AllowedIPs, addr)
+}
+
+// remoteIP returns the source IP of the request.
+//
+// RemoteIPHeader is honoured only when the request comes from a trusted proxy.
+// Clients can put any address into the header, and each proxy appends the address it received
+// the request from, so the header is read from the right, skipping the trusted proxies,
+// and the first address not trusted is the source IP.
+func (h *Handler) remoteIP(r *http.Request) (netip.Addr, bool) {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	addr, err := netip.ParseAddr(remote)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	addr = addr.Unmap()
+
+	if h.RemoteIPHeader == "" || !containsIP(h.TrustedProxies, addr) {
+		return addr, true
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values(h.RemoteIPHeader), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		entry := strings.TrimSpace(forwarded[i])
+		if entry == "" {
+			continue
+		}
+		hop, err := netip.ParseAddr(entry)
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		addr = hop.Unmap()
+		if !containsIP(h.TrustedProxies, addr) {
+			break
+		}
+	}
+	return addr, true
+}
+
+// containsIP reports whether any of the prefixes contains the address.
+func containsIP(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse verifies and decrypts the notification body.
+func (h *Handler) parse(body []byte) (*Notification, error) {
+	// Verify and decrypt the notification
+	decryptedData, err := h.client.VerifyAndDecryptNotification(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if decryptedData == "" {
+		return nil, fmt.Errorf("empty callback data")
+	}
+
+	envelope, err := cores.ParseResponseMessage(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		FunCode: envelope.FunCode,
+		Data:    decryptedData,
+	}, nil
+}
+
+// dispatch passes the notification to its hook.
+func (h *Handler) dispatch(ctx context.Context, notification *Notification) error {
+	switch notification.FunCode {
+	case cores.FunCodePayment.Code, cores.FunCodeOneClickPayment.Code:
+		if h.OnPayment != nil {
+			var result payments.PaymentResult
+			if err := unmarshal(notification, &result); err != nil {
+				return err
+			}
+			return h.once(ctx, PaymentKey(&result), func(ctx context.Context) error {
+				return h.OnPayment(ctx, &result)
+			})
+		}
+	case cores.FunCodeSignContract.Code, cores.FunCodeSignH5.Code:
+		if h.OnSign != nil {
+			var result freelancers.SignContractResult
+			if err := unmarshal(notification, &result); err != nil {
+				return err
+			}
+			return h.once(ctx, SignKey(&result), func(ctx context.Context) error {
+				return h.OnSign(ctx, &result)
+			})
+		}
+	case cores.FunCodeRechargeApply.Code:
+		if h.OnRecharge != nil {
+			var result recharges.RechargeCallbackResult
+			if err := unmarshal(notification, &result); err != nil {
+				return err
+			}
+			return h.once(ctx, RechargeKey(&result), func(ctx context.Context) error {
+				return h.OnRecharge(ctx, &result)
+			})
+		}
+	}
+
+	if h.OnMerchantRecharge != nil {
+		var result recharges.MerchantRechargeCallbackResult
+		if err := unmarshal(notification, &result); err != nil {
+			return err
+		}
+		return h.once(ctx, MerchantRechargeKey(&result), func(ctx context.Context) error {
+			return h.OnMerchantRecharge(ctx, &result)
+		})
+	}
+
+	if h.OnRaw != nil {
+		return h.OnRaw(ctx, notification)
+	}
+	return fmt.Errorf("no hook for notification of funCode %q", notification.FunCode)
+}
+
+// once runs the hook once per key if the store is set.
+func (h *Handler) once(ctx context.Context, key string, hook func(ctx context.Context) error) error {
+	if h.Store == nil {
+		return hook(ctx)
+	}
+	return Once(ctx, h.Store, key, hook)
+}
+
+// unmarshal unmarshals the notification data.
+func unmarshal(notification *Notification, v any) error {
+	if err := json.Unmarshal([]byte(notification.Data), v); err != nil {
+		return fmt.Errorf("failed to parse callback data: %w", err)
+	}
+	return nil
+}
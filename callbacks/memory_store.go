@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbacks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryEntry is the state of a key in MemoryStore.
+type memoryEntry struct {
+	completed bool      // whether the key has been processed
+	token     string    // the token of the processing claim
+	expiresAt time.Time // the time the claim or the processed record expires
+}
+
+// MemoryStore is an in-memory Store for a single process.
+type MemoryStore struct {
+	TTL               time.Duration // the time a processed key is remembered
+	ProcessingTimeout time.Duration // the time a processing claim is held
+
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	nextSweep time.Time
+}
+
+// NewMemoryStore creates an in-memory store remembering processed keys for ttl,
+// using DefaultDedupTTL if ttl is not positive.
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+	return &MemoryStore{
+		TTL:               ttl,
+		ProcessingTimeout: DefaultProcessingTimeout,
+		entries:           make(map[string]*memoryEntry),
+	}
+}
+
+// Acquire claims the key for processing.
+func (s *MemoryStore) Acquire(ctx context.Context, key string) (KeyState, string, error) {
+	token, err := newClaimToken()
+	if err != nil {
+		return 0, "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expiresAt) {
+		if entry.completed {
+			return KeyCompleted, "", nil
+		}
+		return KeyProcessing, "", nil
+	}
+
+	s.entries[key] = &memoryEntry{token: token, expiresAt: now.Add(s.ProcessingTimeout)}
+	return KeyAcquired, token, nil
+}
+
+// Complete marks the key claimed with the token as processed.
+func (s *MemoryStore) Complete(ctx context.Context, key, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// An expired claim swept away has not been taken over
+	if entry, ok := s.entries[key]; ok && (entry.completed || entry.token != token) {
+		return ErrClaimLost
+	}
+
+	s.entries[key] = &memoryEntry{completed: true, expiresAt: time.Now().Add(s.TTL)}
+	return nil
+}
+
+// Release gives up the claim of the token.
+func (s *MemoryStore) Release(ctx context.Context, key, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[key]; ok && !entry.completed && entry.token == token {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// sweep removes expired entries at most once per processing timeout.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+	s.nextSweep = now.Add(s.ProcessingTimeout)
+}
@@ -0,0 +1,188 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbacks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSQLStoreTable is the default table name of SQLStore.
+const DefaultSQLStoreTable = "serviceshare_callback_dedup"
+
+// SQLDialect represents the SQL dialect of a database driver.
+type SQLDialect int
+
+const (
+	DialectMySQL    SQLDialect = iota // MySQL, ? parameters
+	DialectPostgres                   // PostgreSQL, $1 parameters
+	DialectSQLite                     // SQLite, ? parameters
+)
+
+// nowMillis returns the expression of the current database time in unix milliseconds.
+func (d SQLDialect) nowMillis() string {
+	switch d {
+	case DialectPostgres:
+		return "CAST(EXTRACT(EPOCH FROM CLOCK_TIMESTAMP()) * 1000 AS BIGINT)"
+	case DialectSQLite:
+		return "CAST((julianday('now') - 2440587.5) * 86400000 AS INTEGER)"
+	default:
+		return "CAST(UNIX_TIMESTAMP(NOW(3)) * 1000 AS SIGNED)"
+	}
+}
+
+// State column values of SQLStore.
+const (
+	sqlStateProcessing = 1 // the key is claimed for processing
+	sqlStateCompleted  = 2 // the key has been processed
+)
+
+// SQLStore is a Store backed by a database/sql table, shared by all processes using the database.
+//
+// IMPORTANT NOTES:
+// - The table is created by CreateTable, or manually with the columns dedup_key (primary key), state, token and expires_at (unix milliseconds).
+// - Expiry is computed with the database clock, so the clocks of the processes do not matter.
+// - The table name is put into the statements as is, so it must not come from untrusted input.
+// - Expired rows are taken over on acquire and are not deleted, so purge them periodically if needed.
+type SQLStore struct {
+	Table             string        // the table name (default: DefaultSQLStoreTable)
+	Dialect           SQLDialect    // the SQL dialect of the driver (default: DialectMySQL)
+	TTL               time.Duration // the time a processed key is remembered
+	ProcessingTimeout time.Duration // the time a processing claim is held
+
+	db *sql.DB
+}
+
+// NewSQLStore creates a store on the database remembering processed keys for ttl,
+// using DefaultDedupTTL if ttl is not positive.
+func NewSQLStore(db *sql.DB, ttl time.Duration) *SQLStore {
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+	return &SQLStore{
+		Table:             DefaultSQLStoreTable,
+		TTL:               ttl,
+		ProcessingTimeout: DefaultProcessingTimeout,
+		db:                db,
+	}
+}
+
+// CreateTable creates the dedup table if it does not exist.
+func (s *SQLStore) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+s.Table+" ("+
+		"dedup_key VARCHAR(191) NOT NULL PRIMARY KEY, "+
+		"state SMALLINT NOT NULL, "+
+		"token VARCHAR(32) NOT NULL, "+
+		"expires_at BIGINT NOT NULL)")
+	if err != nil {
+		return fmt.Errorf("failed to create table %s: %w", s.Table, err)
+	}
+	return nil
+}
+
+// Acquire claims the key for processing.
+func (s *SQLStore) Acquire(ctx context.Context, key string) (KeyState, string, error) {
+	token, err := newClaimToken()
+	if err != nil {
+		return 0, "", err
+	}
+	now := s.Dialect.nowMillis()
+
+	// Take over an expired claim or processed record
+	result, err := s.db.ExecContext(ctx,
+		s.bind("UPDATE "+s.Table+" SET state = ?, token = ?, expires_at = "+now+" + ? WHERE dedup_key = ? AND expires_at <= "+now),
+		sqlStateProcessing, token, s.ProcessingTimeout.Milliseconds(), key)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to update %s: %w", s.Table, err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows > 0 {
+		return KeyAcquired, token, nil
+	}
+
+	// The primary key lets only one of concurrent inserts succeed
+	_, insertErr := s.db.ExecContext(ctx,
+		s.bind("INSERT INTO "+s.Table+" (dedup_key, state, token, expires_at) VALUES (?, ?, ?, "+now+" + ?)"),
+		key, sqlStateProcessing, token, s.ProcessingTimeout.Milliseconds())
+	if insertErr == nil {
+		return KeyAcquired, token, nil
+	}
+
+	var state int
+	err = s.db.QueryRowContext(ctx,
+		s.bind("SELECT state FROM "+s.Table+" WHERE dedup_key = ?"), key).Scan(&state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, "", fmt.Errorf("failed to insert %s: %w", s.Table, insertErr)
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to query %s: %w", s.Table, err)
+	}
+
+	if state == sqlStateCompleted {
+		return KeyCompleted, "", nil
+	}
+	return KeyProcessing, "", nil
+}
+
+// Complete marks the key claimed with the token as processed.
+func (s *SQLStore) Complete(ctx context.Context, key, token string) error {
+	result, err := s.db.ExecContext(ctx,
+		s.bind("UPDATE "+s.Table+" SET state = ?, expires_at = "+s.Dialect.nowMillis()+" + ? WHERE dedup_key = ? AND token = ? AND state = ?"),
+		sqlStateCompleted, s.TTL.Milliseconds(), key, token, sqlStateProcessing)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", s.Table, err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return ErrClaimLost
+	}
+	return nil
+}
+
+// Release gives up the claim of the token.
+func (s *SQLStore) Release(ctx context.Context, key, token string) error {
+	_, err := s.db.ExecContext(ctx,
+		s.bind("DELETE FROM "+s.Table+" WHERE dedup_key = ? AND token = ? AND state = ?"),
+		key, token, sqlStateProcessing)
+	if err != nil {
+		return fmt.Errorf("failed to delete from %s: %w", s.Table, err)
+	}
+	return nil
+}
+
+// bind rewrites ? parameters into the parameter style of the dialect.
+func (s *SQLStore) bind(query string) string {
+	if s.Dialect != DialectPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -19,15 +19,15 @@ package cores
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"math/rand"
 	"net/http"
 	"time"
-
-	"github.com/vogo/vogo/vlog"
 )
 
 // Client represents the ServiceShare API client.
@@ -36,6 +36,7 @@ type Client struct {
 	httpClient        *http.Client
 	privateKey        *rsa.PrivateKey
 	platformPublicKey *rsa.PublicKey
+	logger            *slog.Logger
 }
 
 // NewClient creates a new ServiceShare API client.
@@ -45,6 +46,9 @@ func NewClient(config *Config) (*Client, error) {
 		return nil, err
 	}
 
+	// Keep a copy so that later changes of the caller's config do not race with requests
+	config = config.clone()
+
 	// Parse private key
 	privateKey, err := ParsePrivateKey(config.PrivateKey)
 	if err != nil {
@@ -62,14 +66,48 @@ func NewClient(config *Config) (*Client, error) {
 		Timeout: config.Timeout,
 	}
 
+	// Stay quiet unless a logger is configured
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
 	return &Client{
 		config:            config,
 		httpClient:        httpClient,
 		privateKey:        privateKey,
 		platformPublicKey: platformPublicKey,
+		logger:            logger,
 	}, nil
 }
 
+// Config returns a copy of the configuration of the client.
+// Changing the copy does not affect the client.
+func (c *Client) Config() *Config {
+	return c.config.clone()
+}
+
+// MerchantID returns the merchant ID of the client without copying the configuration.
+func (c *Client) MerchantID() string {
+	return c.config.MerchantID
+}
+
+// RedactValue returns the data redacted by the redaction policy of the client for logging.
+func (c *Client) RedactValue(data string) slog.LogValuer {
+	return c.config.RedactPolicy.Value(data)
+}
+
+// HTTPClient returns the HTTP client used by the client, e.g. for downloading files
+// whose URLs are returned by the platform.
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+// Logger returns the logger of the client, which discards records unless Config.Logger is set.
+func (c *Client) Logger() *slog.Logger {
+	return c.logger
+}
+
 // generateRequestID generates a unique request ID using timestamp and random number.
 func (c *Client) generateRequestID() string {
 	timestamp := time.Now().Unix()
@@ -80,18 +118,83 @@ func (c *Client) generateRequestID() string {
 // Do executes an API request with encryption and signing.
 // Returns decrypted response data as JSON string.
 func (c *Client) Do(funCode *FunCode, reqData interface{}) (string, error) {
-	// 1. Generate unique request ID
-	reqId := c.generateRequestID()
+	return c.DoContext(context.Background(), funCode, reqData)
+}
 
-	// 2. Marshal request data to JSON
+// DoContext is like Do but carries the context for cancellation and deadlines.
+// The context is checked before signing and propagated to the HTTP request;
+// its error is wrapped in the returned error once it is done.
+//
+// Failed attempts are retried according to Config.RetryPolicy; a retry rejected as a
+// duplicate returns an error wrapping ErrRetriedDuplicate.
+func (c *Client) DoContext(ctx context.Context, funCode *FunCode, reqData interface{}) (string, error) {
+	// 1. Marshal request data to JSON once, every attempt sends the same data
+	// so that retried writes keep their idempotency key
 	reqDataJSON, err := json.Marshal(reqData)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal request data: %w", err)
 	}
 	reqDataString := string(reqDataJSON)
 
-	vlog.Infof("service share api request | merchantId: %s | funCode: %s(%s) | reqId: %s  | url: %s | reqData: %s",
-		c.config.MerchantID, funCode.Code, funCode.Name, reqId, c.config.BaseURL, reqDataString)
+	policy := c.config.RetryPolicy
+	maxAttempts := policy.maxAttempts(funCode, reqDataJSON)
+
+	// Whether an earlier attempt failed in a way that it may have been processed
+	processed := false
+	for attempt := 1; ; attempt++ {
+		respData, err := c.send(ctx, funCode, reqDataString)
+		if processed && isDuplicate(err) {
+			return "", fmt.Errorf("%w: %w", ErrRetriedDuplicate, err)
+		}
+		if err == nil || attempt >= maxAttempts || ctx.Err() != nil || !policy.retryable(err) {
+			return respData, err
+		}
+		processed = processed || isAmbiguous(err)
+
+		delay := policy.backoff(attempt)
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "service share api retry",
+			slog.String("merchantId", c.config.MerchantID),
+			slog.String("funCode", funCode.Code),
+			slog.String("funName", funCode.Name),
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+			slog.Any("err", err),
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", fmt.Errorf("%w: %w", ErrRequestFailed, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// send executes a single attempt of an API request with a new request ID.
+func (c *Client) send(ctx context.Context, funCode *FunCode, reqDataString string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrRequestFailed, err)
+	}
+
+	// Wait for the rate limiter, every attempt counts against the limit
+	if err := c.acquire(ctx, funCode); err != nil {
+		return "", err
+	}
+
+	// 2. Generate unique request ID
+	reqId := c.generateRequestID()
+
+	logger := c.logger.With(
+		slog.String("merchantId", c.config.MerchantID),
+		slog.String("funCode", funCode.Code),
+		slog.String("funName", funCode.Name),
+		slog.String("reqId", reqId),
+	)
+	logger.LogAttrs(ctx, slog.LevelDebug, "service share api request",
+		slog.String("url", c.config.BaseURL),
+		slog.Any("reqData", c.config.RedactPolicy.Value(reqDataString)),
+	)
 
 	// 3. Encrypt request data with DES
 	encryptedData, err := EncryptDES(reqDataString, c.config.DesKey)
@@ -115,6 +218,10 @@ func (c *Client) Do(funCode *FunCode, reqData interface{}) (string, error) {
 	}
 	requestMsg.Sign = signature
 
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrRequestFailed, err)
+	}
+
 	// 6. Marshal request message to JSON
 	requestJSON, err := requestMsg.ToJSON()
 	if err != nil {
@@ -122,7 +229,7 @@ func (c *Client) Do(funCode *FunCode, reqData interface{}) (string, error) {
 	}
 
 	// 7. Send HTTP POST request
-	req, err := http.NewRequest("POST", c.config.BaseURL, bytes.NewReader(requestJSON))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.config.BaseURL, bytes.NewReader(requestJSON))
 	if err != nil {
 		return "", fmt.Errorf("%w: %v", ErrRequestFailed, err)
 	}
@@ -130,38 +237,65 @@ func (c *Client) Do(funCode *FunCode, reqData interface{}) (string, error) {
 	req.Header.Set("Content-Type", "application/json;charset=utf-8")
 	req.Header.Set("Accept", "application/json")
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("%w: %v", ErrRequestFailed, err)
+		logger.LogAttrs(ctx, slog.LevelError, "service share api request failed",
+			slog.Duration("latency", time.Since(start)),
+			slog.Any("err", err),
+		)
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", fmt.Errorf("%w: %w", ErrRequestFailed, ctxErr)
+		}
+		return "", fmt.Errorf("%w: %w", ErrRequestFailed, &transportError{err: err, sent: isTimeout(err)})
 	}
 	defer resp.Body.Close()
 
 	// 8. Read response body
 	respBody, err := io.ReadAll(resp.Body)
+	latency := time.Since(start)
 	if err != nil {
-		return "", fmt.Errorf("%w: failed to read response: %v", ErrRequestFailed, err)
+		logger.LogAttrs(ctx, slog.LevelError, "service share api response read failed",
+			slog.Int("httpStatus", resp.StatusCode),
+			slog.Duration("latency", latency),
+			slog.Any("err", err),
+		)
+
+		return "", fmt.Errorf("%w: failed to read response: %w", ErrRequestFailed, &transportError{err: err, sent: true})
 	}
 
 	// Check HTTP status
 	if resp.StatusCode != http.StatusOK {
-		vlog.Errorf("service share api response not ok | merchantId: %s | funCode: %s(%s) | reqId: %s | status_code: %d | respBody: %s",
-			c.config.MerchantID, funCode.Code, funCode.Name, reqId, resp.StatusCode, string(respBody))
+		logger.LogAttrs(ctx, slog.LevelError, "service share api response not ok",
+			slog.Int("httpStatus", resp.StatusCode),
+			slog.Duration("latency", latency),
+			slog.Any("respBody", c.config.RedactPolicy.Value(string(respBody))),
+		)
 
-		return "", fmt.Errorf("%w: HTTP %d", ErrRequestFailed, resp.StatusCode)
+		return "", fmt.Errorf("%w: %w", ErrRequestFailed, &httpStatusError{statusCode: resp.StatusCode})
 	}
 
-	vlog.Infof("service share api response | merchantId: %s | funCode: %s(%s) | reqId: %s | respBody: %s",
-		c.config.MerchantID, funCode.Code, funCode.Name, reqId, string(respBody))
-
 	// 9. Parse response message
 	responseMsg, err := ParseResponseMessage(respBody)
 	if err != nil {
-		vlog.Errorf("service share api response parse failed | merchantId: %s | funCode: %s(%s) | reqId: %s | respBody: %s | err: %v",
-			c.config.MerchantID, funCode.Code, funCode.Name, reqId, string(respBody), err)
+		logger.LogAttrs(ctx, slog.LevelError, "service share api response parse failed",
+			slog.Int("httpStatus", resp.StatusCode),
+			slog.Duration("latency", latency),
+			slog.Any("respBody", c.config.RedactPolicy.Value(string(respBody))),
+			slog.Any("err", err),
+		)
 
 		return "", fmt.Errorf("%w: %v", ErrInvalidResponse, err)
 	}
 
+	logger.LogAttrs(ctx, slog.LevelInfo, "service share api response",
+		slog.Int("httpStatus", resp.StatusCode),
+		slog.Duration("latency", latency),
+		slog.String("resCode", responseMsg.ResCode),
+		slog.String("resMsg", responseMsg.ResMsg),
+	)
+
 	// 10. Check API response code
 	if !responseMsg.IsSuccess() {
 		return "", responseMsg.GetError()
@@ -184,12 +318,33 @@ func (c *Client) Do(funCode *FunCode, reqData interface{}) (string, error) {
 		return "", fmt.Errorf("failed to decrypt response data: %w", decryptErr)
 	}
 
-	vlog.Infof("service share api response decrypt | funCode: %s(%s) | reqId: %s | decryptedData: %s",
-		funCode.Code, funCode.Name, reqId, decryptedData)
+	logger.LogAttrs(ctx, slog.LevelDebug, "service share api response decrypt",
+		slog.Any("resData", c.config.RedactPolicy.Value(decryptedData)),
+	)
 
 	return decryptedData, nil
 }
 
+// acquire takes a rate limiter token for the function code according to the rate limit mode.
+func (c *Client) acquire(ctx context.Context, funCode *FunCode) error {
+	limiter := c.config.RateLimiter
+	if limiter == nil {
+		return nil
+	}
+
+	if c.config.RateLimitMode == RateLimitFailFast {
+		if !limiter.Allow(funCode) {
+			return fmt.Errorf("%w: funCode %s", ErrRateLimited, funCode.Code)
+		}
+		return nil
+	}
+
+	if err := limiter.Wait(ctx, funCode); err != nil {
+		return fmt.Errorf("%w: %w", ErrRequestFailed, err)
+	}
+	return nil
+}
+
 // VerifyAndDecryptNotification verifies the signature and decrypts the notification data.
 // It accepts the raw JSON body of the notification request.
 func (c *Client) VerifyAndDecryptNotification(body []byte) (string, error) {
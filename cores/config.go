@@ -19,6 +19,9 @@ package cores
 
 import (
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
 	"time"
 )
 
@@ -32,6 +35,11 @@ type Config struct {
 	PlatformPublicKey string        // the platform's RSA public key in PEM format
 	Timeout           time.Duration // the HTTP request timeout (default: 60 seconds)
 	TaskID            int64         // the task identifier for the request
+	RetryPolicy       *RetryPolicy  // the retry policy for communication-level failures (default: nil, no retries)
+	RateLimiter       *RateLimiter  // the client-side rate limiter per function code (default: nil, no limit)
+	RateLimitMode     RateLimitMode // what a call does when the rate limit is reached (default: block)
+	Logger            *slog.Logger  // the structured logger, plaintext data is logged at debug level (default: nil, discard)
+	RedactPolicy      *RedactPolicy // the redaction of logged request and response data (default: DefaultRedactPolicy)
 }
 
 // NewConfig creates a new Config with default values.
@@ -74,5 +82,27 @@ func (c *Config) Validate() error {
 	if c.Timeout == 0 {
 		c.Timeout = 60 * time.Second
 	}
+	if c.RedactPolicy == nil {
+		c.RedactPolicy = DefaultRedactPolicy()
+	}
 	return nil
 }
+
+// clone returns a copy of the configuration with its own retry and redact policies.
+// The rate limiter and logger are shared, being safe for concurrent use.
+func (c *Config) clone() *Config {
+	clone := *c
+	if c.RetryPolicy != nil {
+		policy := *c.RetryPolicy
+		policy.RetryCodes = slices.Clone(policy.RetryCodes)
+		policy.RetryHTTPStatuses = slices.Clone(policy.RetryHTTPStatuses)
+		policy.RetryWrites = slices.Clone(policy.RetryWrites)
+		clone.RetryPolicy = &policy
+	}
+	if c.RedactPolicy != nil {
+		policy := *c.RedactPolicy
+		policy.Fields = maps.Clone(policy.Fields)
+		clone.RedactPolicy = &policy
+	}
+	return &clone
+}
@@ -17,6 +17,11 @@
 
 package cores
 
+import "time"
+
+// Location is the time zone the platform uses for all dates and times (China Standard Time).
+var Location = time.FixedZone("CST", 8*60*60)
+
 // PaymentType represents the payment method type.
 type PaymentType string
 
@@ -37,6 +42,27 @@ var (
 	FunCodePayment           = &FunCode{Code: "6001", Name: "payment"}             // function code for payment
 	FunCodePaymentQuery      = &FunCode{Code: "6002", Name: "payment_query"}       // function code for payment query
 	FunCodeBalanceQuery      = &FunCode{Code: "6003", Name: "balance_query"}       // function code for balance query
+	FunCodeBillDownload      = &FunCode{Code: "6004", Name: "bill_download"}       // function code for reconciliation file download
+	FunCodeQuotaQuery        = &FunCode{Code: "6005", Name: "quota_query"}         // function code for freelancer remaining quota query
+	FunCodeTrialCalculate    = &FunCode{Code: "6006", Name: "trial_calculate"}     // function code for continuous-labour tax trial calculation
 	FunCodeSignContract      = &FunCode{Code: "6010", Name: "sign_contract"}       // function code for contract signing
 	FunCodeSignContractQuery = &FunCode{Code: "6011", Name: "sign_contract_query"} // function code for contract status query
+	FunCodeInvoiceAmount     = &FunCode{Code: "6012", Name: "invoice_amount"}      // function code for invoiceable amount query
+	FunCodeInvoiceApply      = &FunCode{Code: "6013", Name: "invoice_apply"}       // function code for invoice application
+	FunCodeInvoiceResult     = &FunCode{Code: "6014", Name: "invoice_result"}      // function code for invoice result query
+	FunCodeInvoiceCategory   = &FunCode{Code: "6015", Name: "invoice_category"}    // function code for invoice category query
+	FunCodeRechargeRecord    = &FunCode{Code: "6018", Name: "recharge_record"}     // function code for recharge record query
+	FunCodeRechargeAmount    = &FunCode{Code: "6019", Name: "recharge_amount"}     // function code for rechargeable amount query
+	FunCodeRechargeApply     = &FunCode{Code: "6020", Name: "recharge_apply"}      // function code for recharge (profit-sharing) application
+	FunCodeRechargeResult    = &FunCode{Code: "6021", Name: "recharge_result"}     // function code for recharge result query
+	FunCodeBatchUpload       = &FunCode{Code: "6022", Name: "batch_upload"}        // function code for batch order upload
+	FunCodeBatchUploadQuery  = &FunCode{Code: "6023", Name: "batch_upload_query"}  // function code for uploaded batch order query
+	FunCodeReceiptQuery      = &FunCode{Code: "6024", Name: "receipt_query"}       // function code for electronic receipt query
+	FunCodeSignH5            = &FunCode{Code: "6026", Name: "sign_h5"}             // function code for H5 interactive contract signing
+	FunCodeTaskList          = &FunCode{Code: "6031", Name: "task_list"}           // function code for task list query
+	FunCodeOneClickPayment   = &FunCode{Code: "6029", Name: "one_click_payment"}   // function code for one-click payment
+	FunCodeOneClickQuery     = &FunCode{Code: "6030", Name: "one_click_query"}     // function code for one-click payment query
+	FunCodeCancelContract    = &FunCode{Code: "6036", Name: "cancel_contract"}     // function code for freelancer contract termination
+	FunCodeContractList      = &FunCode{Code: "6044", Name: "contract_list"}       // function code for paged contract listing
+	FunCodeTransferCancel    = &FunCode{Code: "6043", Name: "transfer_cancel"}     // function code for cancelling a pending WeChat transfer
 )
@@ -57,6 +57,7 @@ var (
 	ErrRequestFailed      = fmt.Errorf("request failed")
 	ErrInvalidResponse    = fmt.Errorf("invalid response")
 	ErrInvalidKey         = fmt.Errorf("invalid key format")
+	ErrRetriedDuplicate   = fmt.Errorf("retried request rejected as duplicate, an earlier attempt reached the platform")
 )
 
 // API Business Errors
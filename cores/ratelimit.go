@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cores
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimit is the platform's default limit of calls per second to the same
+// function code by the same merchant.
+const DefaultRateLimit = 20
+
+// RateLimitMode represents what a call does when the rate limit is reached.
+type RateLimitMode int
+
+const (
+	RateLimitBlock    RateLimitMode = 0 // waits until a token frees up or the context is done
+	RateLimitFailFast RateLimitMode = 1 // fails at once with ErrRateLimited
+)
+
+// ErrRateLimited is returned in RateLimitFailFast mode when no token is available.
+var ErrRateLimited = fmt.Errorf("rate limit exceeded")
+
+// RateLimiter limits the calls per function code with token buckets.
+// It is safe for concurrent use and can be shared by clients of the same merchant.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   int
+	limits  map[string]rateLimit
+	buckets map[string]*tokenBucket
+}
+
+// rateLimit represents the rate and burst of a function code.
+type rateLimit struct {
+	rate  float64
+	burst int
+}
+
+// tokenBucket represents the tokens left for a function code.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a new rate limiter allowing rate calls per second with
+// bursts of up to burst calls for each function code.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:    rate,
+		burst:   max(burst, 1),
+		limits:  make(map[string]rateLimit),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+var (
+	sharedRateLimitersMu sync.Mutex
+	sharedRateLimiters   = make(map[string]*RateLimiter)
+)
+
+// SharedRateLimiter returns the rate limiter shared by all clients of the merchant on the
+// gateway at baseURL, creating it with DefaultRateLimit calls per second and no bursts on first use.
+// Clients of the same merchant on different gateways, e.g. test and production, do not share it.
+func SharedRateLimiter(baseURL, merchantID string) *RateLimiter {
+	sharedRateLimitersMu.Lock()
+	defer sharedRateLimitersMu.Unlock()
+
+	key := baseURL + "|" + merchantID
+	limiter, ok := sharedRateLimiters[key]
+	if !ok {
+		limiter = NewRateLimiter(DefaultRateLimit, 1)
+		sharedRateLimiters[key] = limiter
+	}
+	return limiter
+}
+
+// SetRate sets the rate per second and burst of the function code, e.g. after the
+// platform has raised its limit for the merchant.
+func (l *RateLimiter) SetRate(funCode *FunCode, rate float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limits[funCode.Code] = rateLimit{rate: rate, burst: max(burst, 1)}
+	delete(l.buckets, funCode.Code)
+}
+
+// Allow reports whether a call to the function code can be made now, taking a token if so.
+func (l *RateLimiter) Allow(funCode *FunCode) bool {
+	ok, _ := l.take(funCode.Code, time.Now())
+	return ok
+}
+
+// Wait blocks until a call to the function code can be made or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context, funCode *FunCode) error {
+	for {
+		ok, wait := l.take(funCode.Code, time.Now())
+		if ok {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take takes a token of the function code, or returns how long until one frees up.
+func (l *RateLimiter) take(code string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit, ok := l.limits[code]
+	if !ok {
+		limit = rateLimit{rate: l.rate, burst: l.burst}
+	}
+	if limit.rate <= 0 {
+		return true, 0
+	}
+
+	bucket, ok := l.buckets[code]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit.burst), last: now}
+		l.buckets[code] = bucket
+	}
+
+	// Refill the tokens since the last call
+	elapsed := now.Sub(bucket.last).Seconds()
+	bucket.tokens = min(bucket.tokens+elapsed*limit.rate, float64(limit.burst))
+	bucket.last = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	return false, time.Duration((1 - bucket.tokens) / limit.rate * float64(time.Second))
+}
@@ -0,0 +1,183 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cores
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactMaxLen is the default length beyond which logged string values are truncated.
+const DefaultRedactMaxLen = 256
+
+// redactMask replaces the masked part of a value.
+const redactMask = "****"
+
+// digitRunPattern matches runs of 11 or more digits, optionally ending with the ID card check
+// character X, as ID card, bank account and mobile numbers in data that is not JSON.
+var digitRunPattern = regexp.MustCompile(`\d{10,}[\dXx]`)
+
+// RedactRule represents how the value of a sensitive field is masked.
+// The characters outside the kept prefix and suffix are replaced by "****",
+// and values not longer than the kept parts are masked entirely.
+type RedactRule struct {
+	KeepPrefix int // the number of leading characters kept
+	KeepSuffix int // the number of trailing characters kept
+}
+
+// RedactPolicy configures how request and response data is redacted before it is logged.
+type RedactPolicy struct {
+	Fields     map[string]RedactRule // the sensitive JSON field names and how to mask them
+	MaxLen     int                   // the length beyond which string values are truncated, 0 for no limit
+	MaskDigits bool                  // whether runs of 11 or more digits in data that is not JSON are masked
+}
+
+// DefaultRedactPolicy returns the redaction policy masking the freelancer's personal data,
+// ID card photos and bank account numbers, and truncating values beyond DefaultRedactMaxLen.
+// Data that is not JSON, e.g. plain text gateway errors, has its long digit runs masked.
+func DefaultRedactPolicy() *RedactPolicy {
+	idNumber := RedactRule{KeepPrefix: 3, KeepSuffix: 4}
+	name := RedactRule{KeepPrefix: 1}
+	hidden := RedactRule{}
+
+	return &RedactPolicy{
+		Fields: map[string]RedactRule{
+			"idCard":          idNumber,
+			"idCardNo":        idNumber,
+			"idcardNo":        idNumber,
+			"mobile":          idNumber,
+			"cardNo":          idNumber,
+			"payeeAcc":        idNumber,
+			"receiveBankNo":   idNumber,
+			"payBankNo":       idNumber,
+			"subAccNo":        idNumber,
+			"name":            name,
+			"userName":        name,
+			"payeeName":       name,
+			"idCardPic1":      hidden,
+			"idCardPic2":      hidden,
+			"idCardFrontPic":  hidden,
+			"idCardBackPic":   hidden,
+			"postAddress":     hidden,
+			"receiveBankName": hidden,
+			"payBankName":     hidden,
+		},
+		MaxLen:     DefaultRedactMaxLen,
+		MaskDigits: true,
+	}
+}
+
+// Redact masks the sensitive fields of the JSON data and truncates long string values.
+// Data that is not JSON has its long digit runs masked if MaskDigits is set, and is truncated.
+func (p *RedactPolicy) Redact(data string) string {
+	if p == nil {
+		return data
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return p.redactText(data)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(p.redactValue("", value)); err != nil {
+		return p.redactText(data)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// Value returns a log value of the data that is redacted only when the record is logged.
+func (p *RedactPolicy) Value(data string) slog.LogValuer {
+	return redactedData{policy: p, data: data}
+}
+
+// redactedData represents data redacted lazily when logged.
+type redactedData struct {
+	policy *RedactPolicy
+	data   string
+}
+
+// LogValue implements slog.LogValuer.
+func (d redactedData) LogValue() slog.Value {
+	return slog.StringValue(d.policy.Redact(d.data))
+}
+
+// redactValue redacts the value of the named field, walking into objects and arrays.
+func (p *RedactPolicy) redactValue(field string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = p.redactValue(key, item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = p.redactValue(field, item)
+		}
+		return v
+	case string:
+		if rule, ok := p.Fields[field]; ok {
+			return rule.mask(v)
+		}
+		return p.truncate(v)
+	case json.Number:
+		if rule, ok := p.Fields[field]; ok {
+			return rule.mask(v.String())
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// redactText masks the long digit runs of data that is not JSON and truncates it.
+func (p *RedactPolicy) redactText(data string) string {
+	if p.MaskDigits {
+		rule := RedactRule{KeepPrefix: 3, KeepSuffix: 4}
+		data = digitRunPattern.ReplaceAllStringFunc(data, rule.mask)
+	}
+	return p.truncate(data)
+}
+
+// truncate truncates the value beyond MaxLen characters, noting the original length.
+func (p *RedactPolicy) truncate(value string) string {
+	runes := []rune(value)
+	if p.MaxLen <= 0 || len(runes) <= p.MaxLen {
+		return value
+	}
+	return fmt.Sprintf("%s...(%d chars)", string(runes[:p.MaxLen]), len(runes))
+}
+
+// mask masks the value according to the rule.
+func (r RedactRule) mask(value string) string {
+	runes := []rune(value)
+	if len(runes) <= r.KeepPrefix+r.KeepSuffix {
+		return redactMask
+	}
+	return string(runes[:r.KeepPrefix]) + redactMask + string(runes[len(runes)-r.KeepSuffix:])
+}
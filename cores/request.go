@@ -74,3 +74,12 @@ func (r *ResponseMessage) GetError() error {
 	}
 	return NewAPIError(r.ResCode, r.ResMsg)
 }
+
+// NotificationAck is the acknowledgement the platform expects after a notification has been handled.
+// Any other reply, or a network timeout, makes the platform resend the notification.
+const NotificationAck = "SUCCESS"
+
+// NotificationAckBody returns the response body acknowledging a successfully handled notification.
+func NotificationAckBody() []byte {
+	return []byte(NotificationAck)
+}
@@ -0,0 +1,212 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cores
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// queryFunCodes are the read-only function codes, which are retried on every policy.
+var queryFunCodes = []string{
+	FunCodePaymentQuery.Code, FunCodeBalanceQuery.Code, FunCodeBillDownload.Code, FunCodeQuotaQuery.Code,
+	FunCodeTrialCalculate.Code, FunCodeSignContractQuery.Code, FunCodeInvoiceAmount.Code, FunCodeInvoiceResult.Code,
+	FunCodeInvoiceCategory.Code, FunCodeRechargeRecord.Code, FunCodeRechargeAmount.Code, FunCodeRechargeResult.Code,
+	FunCodeBatchUploadQuery.Code, FunCodeReceiptQuery.Code, FunCodeOneClickQuery.Code, FunCodeTaskList.Code,
+	FunCodeContractList.Code,
+}
+
+// idempotencyKeys maps the write function codes carrying their own idempotency key to the
+// request field holding it. The platform rejects a repeated key instead of processing it twice.
+var idempotencyKeys = map[string]string{
+	FunCodePayment.Code:         "merBatchId",
+	FunCodeOneClickPayment.Code: "merBatchId",
+	FunCodeBatchUpload.Code:     "merBatchId",
+	FunCodeRechargeApply.Code:   "enterpriseOrderNo",
+}
+
+// duplicateCodes are the API error codes rejecting a repeated idempotency key.
+var duplicateCodes = []string{
+	ErrApiBatchNoDuplicate.Code, ErrApiOrderNoDuplicate.Code, ErrApiRechargeOrderNoDuplicate.Code,
+}
+
+// RetryPolicy configures how the client retries communication-level failures.
+//
+// Only read-only queries are retried by default. Other writes, e.g. invoice application or
+// contract signing, are never retried since a timed-out attempt may have been processed.
+// Writes carrying their own idempotency key are retried when listed in RetryWrites:
+// payouts and batch uploads (6001, 6029, 6022) by merBatchId, recharges (6020) by enterpriseOrderNo.
+//
+// Every attempt sends the same request data with a new request ID, so a retried write keeps
+// its idempotency key. When a retry is rejected as a duplicate (6012, 6051 or 6054) after an
+// attempt with an unknown outcome (6000, 6102, HTTP 504 or a transport failure after sending),
+// that attempt has reached the platform and the error wraps ErrRetriedDuplicate. Payment and
+// OneClickPayment resolve it by querying the batch. After attempts not processed, e.g. 6042 or
+// HTTP 502, the duplicate is the key colliding with an earlier batch and is returned as is.
+//
+// IMPORTANT NOTES:
+// - The platform accepts one recharge (6020) per minute, so a retry of 6020 within the backoff
+// delays is likely rejected with 6042. Raise BaseDelay above a minute if you list it in RetryWrites.
+type RetryPolicy struct {
+	MaxAttempts          int           // the maximum number of attempts including the first, 1 or less disables retries
+	BaseDelay            time.Duration // the delay before the first retry, doubled for each further retry (default: 200ms)
+	MaxDelay             time.Duration // the maximum delay between attempts (default: 5s)
+	RetryCodes           []string      // the API error codes to retry
+	RetryTransportErrors bool          // whether connection failures and timeouts are retried
+	RetryHTTPStatuses    []int         // the non-200 HTTP statuses to retry
+	RetryWrites          []string      // the write function codes retried when carrying their idempotency key (default: none)
+}
+
+// DefaultRetryPolicy returns a retry policy making up to 3 attempts of queries on the
+// communication errors 6000, 6042 and 6102, transport errors and HTTP 502/503/504.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            200 * time.Millisecond,
+		MaxDelay:             5 * time.Second,
+		RetryCodes:           []string{ErrApiUnknown.Code, ErrApiRequestTooFrequent.Code, ErrApiRequestTimeout.Code},
+		RetryTransportErrors: true,
+		RetryHTTPStatuses:    []int{502, 503, 504},
+	}
+}
+
+// maxAttempts returns the number of attempts allowed for the request.
+func (p *RetryPolicy) maxAttempts(funCode *FunCode, reqData []byte) int {
+	if p == nil || p.MaxAttempts <= 1 {
+		return 1
+	}
+	if slices.Contains(queryFunCodes, funCode.Code) {
+		return p.MaxAttempts
+	}
+	if key, ok := idempotencyKeys[funCode.Code]; ok && slices.Contains(p.RetryWrites, funCode.Code) && hasIdempotencyKey(reqData, key) {
+		return p.MaxAttempts
+	}
+	return 1
+}
+
+// isDuplicate reports whether the error rejects a repeated idempotency key.
+func isDuplicate(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && slices.Contains(duplicateCodes, apiErr.Code)
+}
+
+// isAmbiguous reports whether the failed attempt may have been processed by the platform.
+func isAmbiguous(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == ErrApiUnknown.Code || apiErr.Code == ErrApiRequestTimeout.Code
+	}
+
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode == http.StatusGatewayTimeout
+	}
+
+	var transportErr *transportError
+	return errors.As(err, &transportErr) && transportErr.sent
+}
+
+// isTimeout reports whether the transport error is a timeout, which may occur after the request was sent.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryable reports whether the failed attempt can be retried.
+func (p *RetryPolicy) retryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return slices.Contains(p.RetryCodes, apiErr.Code)
+	}
+
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return slices.Contains(p.RetryHTTPStatuses, statusErr.statusCode)
+	}
+
+	var transportErr *transportError
+	if errors.As(err, &transportErr) {
+		return p.RetryTransportErrors
+	}
+
+	return false
+}
+
+// backoff returns the delay after the given attempt: exponential from BaseDelay,
+// capped at MaxDelay, with a random jitter keeping it between half and all of it.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = 200 * time.Millisecond
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = 5 * time.Second
+	}
+
+	delay := base
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// hasIdempotencyKey reports whether the request data carries a non-empty value of the key field.
+func hasIdempotencyKey(reqData []byte, key string) bool {
+	var req map[string]any
+	if json.Unmarshal(reqData, &req) != nil {
+		return false
+	}
+	value, ok := req[key].(string)
+	return ok && value != ""
+}
+
+// transportError is a failure to exchange the HTTP request with the platform.
+type transportError struct {
+	err  error
+	sent bool // whether the request may have been sent
+}
+
+// Error implements the error interface.
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// httpStatusError is a non-200 HTTP response from the platform.
+type httpStatusError struct {
+	statusCode int
+}
+
+// Error implements the error interface.
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d", e.statusCode)
+}
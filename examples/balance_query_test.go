@@ -18,9 +18,12 @@
 package examples
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/vogo/vogo/vos"
 	"github.com/vogo/vservicesharesdk/accounts"
@@ -51,3 +54,36 @@ func TestBalanceQuery(t *testing.T) {
 	fmt.Printf("  Provider ID: %d\n", resp.ProviderID)
 	fmt.Printf("  Balance: %d fen (%.2f CNY)\n", balanceFen, balanceYuan)
 }
+
+func TestBalanceQueryContext(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create accounts service
+	accountService := accounts.NewService(client)
+
+	// Query balance with a per-call deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := accountService.BalanceQueryContext(ctx, &accounts.BalanceQueryRequest{
+		ProviderID: vos.EnvInt64("SS_PROVIDER_ID"),
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		fmt.Printf("Balance query timed out\n")
+		return
+	}
+	if err != nil {
+		log.Fatalf("failed to query balance | err: %v", err)
+	}
+
+	fmt.Printf("Balance: %d fen\n", resp.Balance)
+
+	// A cancelled context fails before anything is sent
+	cancel()
+	if _, err := accountService.BalanceQueryContext(ctx, &accounts.BalanceQueryRequest{
+		ProviderID: vos.EnvInt64("SS_PROVIDER_ID"),
+	}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got: %v", err)
+	}
+}
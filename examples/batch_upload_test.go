@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/payments"
+)
+
+func TestBatchUpload(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create payments service
+	paymentService := payments.NewService(client)
+
+	// Generate unique batch ID
+	batchId := fmt.Sprintf("UPLOAD_%d", time.Now().Unix())
+
+	// Upload batch for approval in the merchant portal
+	resp, err := paymentService.UploadBatch(&payments.PaymentRequest{
+		MerBatchId: batchId,
+		PayItems: []payments.PaymentItem{
+			{
+				MerOrderId:  fmt.Sprintf("ORDER_%d_001", time.Now().Unix()),
+				Amt:         10202, // CNY in fen
+				PayeeName:   vos.EnvString("SS_FREELANCER_NAME"),
+				PayeeAcc:    vos.EnvString("SS_FREELANCER_CARD_NO"),
+				IdCard:      vos.EnvString("SS_FREELANCER_ID_CARD"),
+				Mobile:      vos.EnvString("SS_FREELANCER_MOBILE"),
+				PaymentType: cores.PaymentTypeBankCard, // WeChat is not supported
+				Memo:        "Freelance payment",
+			},
+		},
+		TaskId:     vos.EnvInt64("SS_TASK_ID"),
+		ProviderId: vos.EnvInt64("SS_PROVIDER_ID"),
+	})
+	if err != nil {
+		log.Fatalf("failed to upload batch | err: %v", err)
+	}
+
+	fmt.Printf("Batch Uploaded:\n")
+	fmt.Printf("  Batch ID: %s\n", batchId)
+	fmt.Printf("  Platform Batch No: %d\n", resp.BatchNo)
+	fmt.Printf("  Result: [%s] %s\n", resp.ResCode, resp.ResMsg)
+
+	// Query uploaded batch
+	queryResp, err := paymentService.QueryUploadedBatch(&payments.UploadedBatchQueryRequest{
+		MerBatchId: batchId,
+		// Omit QueryItems to get all orders
+	})
+	if err != nil {
+		log.Fatalf("failed to query uploaded batch | err: %v", err)
+	}
+
+	stateNames := map[payments.BatchOrderState]string{
+		payments.BatchOrderStatePending:    "Pending Payment",
+		payments.BatchOrderStateProcessing: "Processing",
+		payments.BatchOrderStateSuccess:    "Success",
+		payments.BatchOrderStateFailed:     "Failed",
+		payments.BatchOrderStateCancelled:  "Cancelled",
+	}
+
+	fmt.Printf("\nUploaded Batch Query Result:\n")
+	for i, item := range queryResp.QueryItems {
+		fmt.Printf("  [%d] Order: %s\n", i+1, item.MerOrderId)
+		fmt.Printf("      State: %s (%d)\n", stateNames[item.State], item.State)
+		fmt.Printf("      Amount: %d fen (%.2f CNY)\n", item.Amt, float64(item.Amt)/100)
+	}
+}
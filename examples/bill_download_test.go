@@ -0,0 +1,157 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/reconciliation"
+)
+
+func TestDownloadBill(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create reconciliation service
+	reconciliationService := reconciliation.NewService(client)
+
+	// Download yesterday's bill, available after 06:00 today
+	bill, err := reconciliationService.DownloadBill(time.Now().AddDate(0, 0, -1))
+	if errors.Is(err, reconciliation.ErrBillNotReady) {
+		fmt.Printf("Bill file is not ready yet\n")
+		return
+	}
+	if err != nil {
+		log.Fatalf("failed to download bill | err: %v", err)
+	}
+
+	fmt.Printf("Bill of %s: %d records\n", bill.BillDate, len(bill.Records))
+	for i, r := range bill.Records {
+		fmt.Printf("  [%d] Order: %s, Platform Order: %s, Amount: %d fen, Paid: %s\n",
+			i+1, r.MerOrderId, r.OrderNo, r.Amt, r.PayTime.Format(time.DateTime))
+	}
+}
+
+func TestParseBill(t *testing.T) {
+	// Build a minimal statement workbook whose first sheet is sheet2.xml, sorting after sheet10.xml
+	header := `<row r="2"><c r="A2" t="s"><v>0</v></c><c r="B2" t="s"><v>1</v></c><c r="C2" t="s"><v>2</v></c><c r="D2" t="s"><v>3</v></c>` +
+		`<c r="E2" t="s"><v>4</v></c><c r="F2" t="inlineStr"><is><t>收款人账号</t></is></c><c r="G2" t="inlineStr"><is><t>创建时间</t></is></c></row>`
+	workbook := func(sheet string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		parts := map[string]string{
+			"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` +
+				`<sheet name="对账单" sheetId="1" r:id="rId2"/><sheet name="说明" sheetId="2" r:id="rId1"/></sheets></workbook>`,
+			"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/sheet10.xml"/>` +
+				`<Relationship Id="rId2" Target="worksheets/sheet2.xml"/></Relationships>`,
+			"xl/sharedStrings.xml": `<sst><si><t>商户订单号</t></si><si><t>平台订单号</t></si><si><t>付款金额(元)</t></si>` +
+				`<si><t>服务费(元)</t></si><si><t>付款时间</t></si><si><t>ORDER_001</t></si><si><r><t>2005564279</t></r><r><t>308279809</t></r></si></sst>`,
+			"xl/worksheets/sheet10.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>说明</t></is></c></row></sheetData></worksheet>`,
+			"xl/worksheets/sheet2.xml":  sheet,
+		}
+		for name, content := range parts {
+			w, err := zw.Create(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := w.Write([]byte(content)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	data := workbook(`<worksheet><sheetData>` +
+		`<row r="1"><c r="A1" t="inlineStr"><is><t>对账单</t></is></c></row>` + header +
+		`<row r="3"><c r="A3" t="s"><v>5</v></c><c r="B3" t="s"><v>6</v></c><c r="C3"><v>100.02</v></c><c r="D3"><v>6</v></c>` +
+		`<c r="E3" t="inlineStr"><is><t>2025-12-29 16:59:20</t></is></c><c r="F3" t="inlineStr"><is><t>6222021234567890123</t></is></c>` +
+		`<c r="G3" t="inlineStr"><is><t>2025-12-29 16:00:00</t></is></c></row>` +
+		`<row r="4"><c r="A4" t="inlineStr"><is><t>合计</t></is></c><c r="C4"><v>100.02</v></c></row>` +
+		`</sheetData></worksheet>`)
+
+	records, err := reconciliation.ParseBill(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("failed to parse bill: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("unexpected records: %d", len(records))
+	}
+
+	r := records[0]
+	if r.MerOrderId != "ORDER_001" || r.OrderNo != "2005564279308279809" || r.Amt != 10002 || r.Fee != 600 {
+		t.Fatalf("unexpected record: %+v", r)
+	}
+	if r.PayTime.Format(time.DateTime) != "2025-12-29 16:59:20" {
+		t.Fatalf("unexpected pay time: %s", r.PayTime)
+	}
+	if r.PayeeName != "" {
+		t.Fatalf("payee name bound to another column: %s", r.PayeeName)
+	}
+
+	// Ambiguous columns are rejected rather than bound silently
+	data = workbook(`<worksheet><sheetData>` +
+		`<row r="2"><c r="A2" t="s"><v>1</v></c><c r="B2" t="s"><v>4</v></c><c r="C2" t="inlineStr"><is><t>付款时间 </t></is></c></row>` +
+		`</sheetData></worksheet>`)
+	if _, err := reconciliation.ParseBill(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Fatal("expected ambiguous column error")
+	} else {
+		fmt.Printf("ambiguous header: %v\n", err)
+	}
+
+	// Bills without the amount or pay time column are rejected rather than read as zero
+	data = workbook(`<worksheet><sheetData>` +
+		`<row r="2"><c r="A2" t="s"><v>0</v></c><c r="B2" t="s"><v>1</v></c><c r="C2" t="s"><v>4</v></c></row>` +
+		`</sheetData></worksheet>`)
+	if _, err := reconciliation.ParseBill(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Fatal("expected missing amount column error")
+	}
+
+	// Parts claiming a huge uncompressed size are rejected before decompression
+	var bomb bytes.Buffer
+	zw := zip.NewWriter(&bomb)
+	w, err := zw.CreateRaw(&zip.FileHeader{Name: "xl/workbook.xml", Method: zip.Store, UncompressedSize64: 1 << 40, CompressedSize64: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("<a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reconciliation.ParseBill(bytes.NewReader(bomb.Bytes()), int64(bomb.Len())); err == nil {
+		t.Fatal("expected oversized part error")
+	} else {
+		fmt.Printf("oversized part: %v\n", err)
+	}
+
+	// Huge cell references are rejected
+	data = workbook(`<worksheet><sheetData><row r="1"><c r="ZZZZZZZZ1"><v>1</v></c></row></sheetData></worksheet>`)
+	if _, err := reconciliation.ParseBill(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Fatal("expected invalid cell reference error")
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/callbacks"
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/freelancers"
+	"github.com/vogo/vservicesharesdk/payments"
+)
+
+func TestCallbackDedup(t *testing.T) {
+	ctx := context.Background()
+	store := callbacks.NewMemoryStore(time.Hour)
+
+	// Concurrent retries of the same notification run the hook once
+	var runs, processing atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := callbacks.Once(ctx, store, "payment:1001:1", func(ctx context.Context) error {
+				runs.Add(1)
+				time.Sleep(50 * time.Millisecond)
+				return nil
+			})
+			if errors.Is(err, callbacks.ErrProcessing) {
+				processing.Add(1)
+			} else if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("runs=%d processing=%d\n", runs.Load(), processing.Load())
+	if runs.Load() != 1 {
+		t.Fatalf("expected 1 run, got %d", runs.Load())
+	}
+
+	// A processed key is skipped
+	err := callbacks.Once(ctx, store, "payment:1001:1", func(ctx context.Context) error {
+		t.Fatal("processed key run again")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed run releases the key for the resent notification
+	failure := errors.New("database unavailable")
+	if err := callbacks.Once(ctx, store, "recharge:R1", func(ctx context.Context) error { return failure }); !errors.Is(err, failure) {
+		t.Fatalf("expected hook error, got %v", err)
+	}
+	if state, _, err := store.Acquire(ctx, "recharge:R1"); err != nil || state != callbacks.KeyAcquired {
+		t.Fatalf("expected released key to be acquired, got %v %v", state, err)
+	}
+
+	// An abandoned claim expires after the processing timeout
+	key := callbacks.SignKey(&freelancers.SignContractResult{IdCard: "110101199001011234", ProviderId: 1, State: 1})
+	if strings.Contains(key, "110101199001011234") {
+		t.Fatalf("sign key exposes the ID card number: %s", key)
+	}
+	store.ProcessingTimeout = 10 * time.Millisecond
+	state, staleToken, _ := store.Acquire(ctx, key)
+	if state != callbacks.KeyAcquired || staleToken == "" {
+		t.Fatalf("expected acquired with a token, got %v %q", state, staleToken)
+	}
+	if state, token, _ := store.Acquire(ctx, key); state != callbacks.KeyProcessing || token != "" {
+		t.Fatalf("expected processing without a token, got %v %q", state, token)
+	}
+	time.Sleep(20 * time.Millisecond)
+	store.ProcessingTimeout = time.Minute
+	state, token, _ := store.Acquire(ctx, key)
+	if state != callbacks.KeyAcquired || token == staleToken {
+		t.Fatalf("expected expired claim to be acquired with a new token, got %v %q", state, token)
+	}
+
+	// The stale owner can neither release nor complete the new claim
+	if err := store.Release(ctx, key, staleToken); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Complete(ctx, key, staleToken); !errors.Is(err, callbacks.ErrClaimLost) {
+		t.Fatalf("expected ErrClaimLost, got %v", err)
+	}
+	if state, _, _ := store.Acquire(ctx, key); state != callbacks.KeyProcessing {
+		t.Fatalf("expected the new claim to be held, got %v", state)
+	}
+	if err := store.Complete(ctx, key, token); err != nil {
+		t.Fatal(err)
+	}
+	if state, _, _ := store.Acquire(ctx, key); state != callbacks.KeyCompleted {
+		t.Fatalf("expected completed, got %v", state)
+	}
+
+	// The outcome is recorded even if the request is cancelled while the hook runs
+	cancelCtx, cancel := context.WithCancel(ctx)
+	err = callbacks.Once(cancelCtx, ctxStore{store}, "recharge:R2", func(ctx context.Context) error {
+		cancel()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state, _, _ := store.Acquire(ctx, "recharge:R2"); state != callbacks.KeyCompleted {
+		t.Fatalf("expected completed after cancellation, got %v", state)
+	}
+}
+
+// ctxStore fails Complete and Release with a cancelled context, like a database store.
+type ctxStore struct {
+	*callbacks.MemoryStore
+}
+
+func (s ctxStore) Complete(ctx context.Context, key, token string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.MemoryStore.Complete(ctx, key, token)
+}
+
+func (s ctxStore) Release(ctx context.Context, key, token string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.MemoryStore.Release(ctx, key, token)
+}
+
+func TestCallbackHandlerDedup(t *testing.T) {
+	client := createLocalClient(t, "http://127.0.0.1:0", nil)
+
+	var runs atomic.Int32
+	started := make(chan struct{})
+	proceed := make(chan struct{})
+	handler := callbacks.NewHandler(client)
+	handler.Store = callbacks.NewMemoryStore(0)
+	handler.OnPayment = func(ctx context.Context, result *payments.PaymentResult) error {
+		if runs.Add(1) == 1 {
+			close(started)
+			<-proceed
+		}
+		return nil
+	}
+
+	payment := signNotification(t, client, cores.FunCodePayment, `{"orderNo":1001,"merOrderId":"O1","amt":100,"state":1}`)
+	serve := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/callback", bytes.NewReader(payment)))
+		return recorder
+	}
+
+	// A resend arriving while the first is processed is not acknowledged
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- serve() }()
+	<-started
+	if recorder := serve(); recorder.Code == http.StatusOK {
+		t.Fatalf("concurrent resend acknowledged")
+	}
+	close(proceed)
+	if recorder := <-first; recorder.Body.String() != cores.NotificationAck {
+		t.Fatalf("unexpected reply: %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	// A later resend is acknowledged without running the hook
+	if recorder := serve(); recorder.Body.String() != cores.NotificationAck {
+		t.Fatalf("unexpected reply: %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	// A new state of the same order is processed
+	success := signNotification(t, client, cores.FunCodePayment, `{"orderNo":1001,"merOrderId":"O1","amt":100,"state":2}`)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/callback", bytes.NewReader(success)))
+	if recorder.Body.String() != cores.NotificationAck {
+		t.Fatalf("unexpected reply: %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	fmt.Printf("hook runs: %d\n", runs.Load())
+	if runs.Load() != 2 {
+		t.Fatalf("expected 2 hook runs, got %d", runs.Load())
+	}
+}
@@ -18,12 +18,13 @@
 package examples
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"testing"
 
+	"github.com/vogo/vservicesharesdk/callbacks"
 	"github.com/vogo/vservicesharesdk/freelancers"
 	"github.com/vogo/vservicesharesdk/payments"
 )
@@ -32,33 +33,11 @@ func TestCallback(t *testing.T) {
 	// Initialize client using environment variables
 	// Make sure to set SS_MERCHANT_ID, SS_DES_KEY, SS_PRIVATE_KEY, SS_PLATFORM_PUBLIC_KEY
 	client := CreateClient(t)
-	freelancerService := freelancers.NewService(client)
-	paymentService := payments.NewService(client)
 
-	// Define sign callback handler
-	http.HandleFunc("/callback/sign", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
-
-		// Read request body
-		body, err := io.ReadAll(r.Body)
-		if err != nil {
-			log.Printf("Failed to read body: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
-		}
-
-		// Parse callback
-		callback, err := freelancerService.ParseSignContractCallback(body)
-		if err != nil {
-			log.Printf("Failed to parse sign callback: %v", err)
-			w.Header().Set("Content-Type", "application/json")
-			w.Write([]byte(`{"resCode":"9999", "resMsg":"Failed"}`))
-			return
-		}
+	handler := callbacks.NewHandler(client)
 
+	// Define sign callback hook
+	handler.OnSign = func(ctx context.Context, callback *freelancers.SignContractResult) error {
 		fmt.Printf("Received Sign Callback:\n")
 		fmt.Printf("Name: %s\n", callback.Name)
 		fmt.Printf("CardNo: %s\n", callback.CardNo)
@@ -66,48 +45,26 @@ func TestCallback(t *testing.T) {
 		if callback.RetMsg != "" {
 			fmt.Printf("RetMsg: %s\n", callback.RetMsg)
 		}
+		return nil
+	}
 
-		// Return success response
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"resCode":"0000", "resMsg":"Success"}`))
-	})
-
-	// Define batch payment callback handler
-	http.HandleFunc("/callback/payment", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
-
-		// Read request body
-		body, err := io.ReadAll(r.Body)
-		if err != nil {
-			log.Printf("failed to read body | err: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
-		}
-
-		// Parse callback
-		callback, err := paymentService.ParsePaymentCallback(body)
-		if err != nil {
-			log.Printf("failed to parse payment callback | err: %v", err)
-			w.Header().Set("Content-Type", "application/json")
-			w.Write([]byte(`{"resCode":"9999", "resMsg":"Failed"}`))
-			return
-		}
-
+	// Define batch payment callback hook
+	handler.OnPayment = func(ctx context.Context, callback *payments.PaymentResult) error {
 		fmt.Printf("Received Payment Callback:\n")
 		fmt.Printf("  OrderNo=%d Amt=%d State=%d\n", callback.OrderNo, callback.Amt, callback.State)
+		return nil
+	}
 
-		// Return success response
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"resCode":"0000", "resMsg":"Success"}`))
-	})
+	// Define hook for other notification types
+	handler.OnRaw = func(ctx context.Context, notification *callbacks.Notification) error {
+		fmt.Printf("Received Callback: funCode=%s data=%s\n", notification.FunCode, notification.Data)
+		return nil
+	}
 
 	// Start server
+	http.Handle("/callback", handler)
 	fmt.Println("Server listening on :8080")
-	fmt.Println("Use POST /callback/contract for contract signing notifications")
-	fmt.Println("Use POST /callback/payment for batch payment notifications")
+	fmt.Println("Use POST /callback for contract signing and payment notifications")
 	if err := http.ListenAndServe(":8080", nil); err != nil {
 		log.Fatal(err)
 	}
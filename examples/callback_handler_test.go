@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vogo/vservicesharesdk/callbacks"
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/payments"
+	"github.com/vogo/vservicesharesdk/recharges"
+)
+
+// signNotification builds a notification body for the local client,
+// whose platform public key pairs with its own private key.
+func signNotification(t *testing.T, client *cores.Client, funCode *cores.FunCode, data string) []byte {
+	config := client.Config()
+	resData, err := cores.EncryptDES(data, config.DesKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, err := cores.ParsePrivateKey(config.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign, err := cores.Sign(resData, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := json.Marshal(&cores.ResponseMessage{
+		ReqId:   "N0001",
+		FunCode: funCode.Code,
+		MerId:   config.MerchantID,
+		Version: config.Version,
+		ResData: resData,
+		ResCode: "0000",
+		Sign:    sign,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestCallbackHandler(t *testing.T) {
+	client := createLocalClient(t, "http://127.0.0.1:0", nil)
+
+	var handled []string
+	failing := false
+	handler := callbacks.NewHandler(client)
+	handler.OnPayment = func(ctx context.Context, result *payments.PaymentResult) error {
+		if failing {
+			return errors.New("database unavailable")
+		}
+		handled = append(handled, fmt.Sprintf("payment %d state %d", result.OrderNo, result.State))
+		return nil
+	}
+	handler.OnRaw = func(ctx context.Context, notification *callbacks.Notification) error {
+		handled = append(handled, "raw "+notification.FunCode)
+		return nil
+	}
+
+	serve := func(method string, body []byte) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, "/callback", bytes.NewReader(body)))
+		return recorder
+	}
+
+	payment := signNotification(t, client, cores.FunCodePayment, `{"orderNo":1001,"merOrderId":"O1","amt":100,"state":1}`)
+
+	// Acknowledged once the hook succeeds
+	recorder := serve(http.MethodPost, payment)
+	if recorder.Code != http.StatusOK || recorder.Body.String() != cores.NotificationAck {
+		t.Fatalf("unexpected reply: %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	// Not acknowledged when the hook fails, so the platform resends
+	failing = true
+	recorder = serve(http.MethodPost, payment)
+	if recorder.Code == http.StatusOK || strings.Contains(recorder.Body.String(), cores.NotificationAck) {
+		t.Fatalf("failed hook acknowledged: %d %s", recorder.Code, recorder.Body.String())
+	}
+	failing = false
+
+	// Other notification types go to the raw hook
+	recharge := signNotification(t, client, cores.FunCodeRechargeApply, `{"orderNo":"R1","state":1}`)
+	if recorder = serve(http.MethodPost, recharge); recorder.Code != http.StatusOK {
+		t.Fatalf("unexpected reply: %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	// Tampered signature
+	tampered := bytes.Replace(payment, []byte(`"sign":"`), []byte(`"sign":"A`), 1)
+	if recorder = serve(http.MethodPost, tampered); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("tampered notification: %d", recorder.Code)
+	}
+
+	// Method, body size and source ip checks
+	if recorder = serve(http.MethodGet, nil); recorder.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("get: %d", recorder.Code)
+	}
+	handler.MaxBodySize = 16
+	if recorder = serve(http.MethodPost, payment); recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("large body: %d", recorder.Code)
+	}
+	handler.MaxBodySize = callbacks.DefaultMaxBodySize
+
+	allowedIPs, err := callbacks.ParseAllowedIPs("10.0.0.0/8", "192.168.1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler.AllowedIPs = allowedIPs
+	if recorder = serve(http.MethodPost, payment); recorder.Code != http.StatusForbidden {
+		t.Fatalf("disallowed ip: %d", recorder.Code)
+	}
+	forwarded := func(header string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/callback", bytes.NewReader(payment))
+		request.Header.Set("X-Forwarded-For", header)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+	handler.RemoteIPHeader = "X-Forwarded-For"
+	if recorder = forwarded("10.1.2.3"); recorder.Code != http.StatusForbidden {
+		t.Fatalf("header from untrusted proxy: %d", recorder.Code)
+	}
+	// httptest requests come from 192.0.2.1
+	handler.TrustedProxies, err = callbacks.ParseAllowedIPs("192.0.2.0/24", "172.31.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorder = forwarded("10.1.2.3, 172.16.0.1"); recorder.Code != http.StatusForbidden {
+		t.Fatalf("spoofed leftmost ip: %d", recorder.Code)
+	}
+	if recorder = forwarded("172.16.0.1, 10.1.2.3, 172.31.0.1"); recorder.Code != http.StatusOK {
+		t.Fatalf("allowed ip: %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	for _, h := range handled {
+		fmt.Printf("handled: %s\n", h)
+	}
+	if len(handled) != 3 {
+		t.Fatalf("unexpected handled notifications: %v", handled)
+	}
+}
+
+func TestCallbackHandlerMerchantRecharge(t *testing.T) {
+	client := createLocalClient(t, "http://127.0.0.1:0", nil)
+
+	// A handler mounted at the callback URL configured in the merchant portal
+	var credited []string
+	handler := callbacks.NewHandler(client)
+	handler.OnMerchantRecharge = func(ctx context.Context, result *recharges.MerchantRechargeCallbackResult) error {
+		credited = append(credited, fmt.Sprintf("%s %d from %s", result.OrderNo, result.AccountingAmt, result.PayBankNo))
+		return nil
+	}
+
+	recharge := signNotification(t, client, cores.FunCodeRechargeApply,
+		`{"orderNo":"R1","enterpriseOrderNo":"E1","rechargeAmt":10000,"accountingAmt":10000,"rechargeState":"SUCCESS","payBankNo":"6222021234567890123"}`)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/recharge", bytes.NewReader(recharge)))
+	if recorder.Body.String() != cores.NotificationAck {
+		t.Fatalf("unexpected reply: %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	fmt.Printf("credited: %v\n", credited)
+	if len(credited) != 1 || credited[0] != "R1 10000 from 6222021234567890123" {
+		t.Fatalf("unexpected recharges: %v", credited)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/freelancers"
+)
+
+func TestCancelContract(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	if vos.EnvString("SS_CANCEL_CONTRACT") != "true" {
+		t.Skip("SS_CANCEL_CONTRACT not set to true")
+	}
+
+	// Create freelancers service
+	freelancerService := freelancers.NewService(client)
+
+	// Terminate the contract with the provider
+	resp, err := freelancerService.CancelContract(&freelancers.CancelContractRequest{
+		UserName:   vos.EnvString("SS_FREELANCER_NAME"),
+		IdcardNo:   vos.EnvString("SS_FREELANCER_ID_CARD"),
+		ProviderId: vos.EnvInt64("SS_PROVIDER_ID"), // Omit to terminate with all providers
+	})
+	if err != nil {
+		log.Fatalf("failed to cancel contract | err: %v", err)
+	}
+
+	fmt.Printf("Cancel Contract Result:\n")
+	fmt.Printf("  State: %s\n", resp.State)
+	if resp.State != freelancers.CancelContractStateSuccess {
+		fmt.Printf("  Message: %s\n", resp.RetMsg)
+		return
+	}
+
+	// Query again, the state is now cancelled
+	signResp, err := freelancerService.SignContractQuery(&freelancers.SignQueryRequest{
+		Name:       vos.EnvString("SS_FREELANCER_NAME"),
+		IdCard:     vos.EnvString("SS_FREELANCER_ID_CARD"),
+		Mobile:     vos.EnvString("SS_FREELANCER_MOBILE"),
+		ProviderId: vos.EnvInt64("SS_PROVIDER_ID"),
+	})
+	if err != nil {
+		log.Fatalf("failed to query sign | err: %v", err)
+	}
+	fmt.Printf("  Sign State: %d (cancelled: %t)\n", signResp.State, signResp.State == freelancers.SignStateCancelled)
+}
@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/freelancers"
+)
+
+func TestListContracts(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create freelancers service
+	freelancerService := freelancers.NewService(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// List the signed contracts created in the last 30 days
+	now := time.Now()
+	count := 0
+	for contract, err := range freelancerService.ListContracts(ctx, &freelancers.ContractListRequest{
+		ProviderId:      vos.EnvInt64("SS_PROVIDER_ID"),
+		CreateTimeBegin: now.AddDate(0, 0, -30),
+		CreateTimeEnd:   now,
+		State:           freelancers.SignStateSigned,
+	}) {
+		if err != nil {
+			log.Fatalf("failed to list contracts | err: %v", err)
+		}
+
+		count++
+		fmt.Printf("  [%d] Name: %s, ID Card: %s, Mobile: %s, State: %d, Offset: %s\n",
+			count, contract.Name, contract.IdCard, contract.Mobile, contract.State, contract.OffsetId)
+	}
+
+	fmt.Printf("Listed %d contracts\n", count)
+}
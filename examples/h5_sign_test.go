@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/freelancers"
+)
+
+func TestH5Sign(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create freelancers service
+	freelancerService := freelancers.NewService(client)
+
+	frontBytes, err := os.ReadFile(vos.EnvString("SS_ID_CARD_FRONT_PATH"))
+	if err != nil {
+		log.Fatalf("failed to read ID card front photo | err: %v", err)
+	}
+	backBytes, err := os.ReadFile(vos.EnvString("SS_ID_CARD_BACK_PATH"))
+	if err != nil {
+		log.Fatalf("failed to read ID card back photo | err: %v", err)
+	}
+
+	// Prepare H5 sign request
+	req := &freelancers.H5SignRequest{
+		UserName:        vos.EnvString("SS_FREELANCER_NAME"),
+		CardNo:          vos.EnvString("SS_FREELANCER_CARD_NO"),
+		IdCard:          vos.EnvString("SS_FREELANCER_ID_CARD"),
+		Mobile:          vos.EnvString("SS_FREELANCER_MOBILE"),
+		PaymentType:     cores.PaymentTypeBankCard,
+		NotifyUrl:       vos.EnvString("SS_NOTIFY_URL"), // Optional callback URL
+		RedirectUrl:     vos.EnvString("SS_REDIRECT_URL"),
+		RedirectBtnName: "Back",
+	}
+	req.SetIdCardPics(frontBytes, backBytes)
+
+	resp, err := freelancerService.CreateH5SignURL(req)
+	if err != nil {
+		log.Fatalf("failed to create H5 sign url | err: %v", err)
+	}
+
+	fmt.Printf("H5 Sign Result:\n")
+	fmt.Printf("  URL: %s\n", resp.URL)
+	fmt.Printf("  Token: %s\n", resp.Token)
+	fmt.Printf("  Expires At: %s\n", resp.ExpiresAt.Format(time.DateTime))
+}
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/invoices"
+)
+
+func TestInvoiceQuery(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create invoices service
+	invoiceService := invoices.NewService(client)
+	providerId := vos.EnvInt64("SS_PROVIDER_ID")
+
+	// Query invoice categories
+	categories, err := invoiceService.QueryInvoiceCategories(&invoices.InvoiceCategoryQueryRequest{
+		ProviderId: providerId,
+	})
+	if err != nil {
+		log.Fatalf("failed to query invoice categories | err: %v", err)
+	}
+
+	fmt.Printf("Invoice Categories:\n")
+	for _, c := range categories {
+		fmt.Printf("  [%d] %s\n", c.InvoiceTypeId, c.InvoiceName)
+	}
+
+	// Query invoiceable amount
+	amount, err := invoiceService.QueryInvoiceAmount(&invoices.InvoiceAmountQueryRequest{
+		ProviderId: providerId,
+	})
+	if err != nil {
+		log.Fatalf("failed to query invoice amount | err: %v", err)
+	}
+
+	fmt.Printf("Invoiceable Amount: %d fen (%.2f CNY)\n", amount.AvailableAmt, float64(amount.AvailableAmt)/100)
+
+	// Query invoice results
+	results, err := invoiceService.QueryInvoiceResult(&invoices.InvoiceResultQueryRequest{
+		ProviderId: providerId,
+	})
+	if err != nil {
+		log.Fatalf("failed to query invoice results | err: %v", err)
+	}
+
+	stateNames := map[invoices.InvoiceState]string{
+		invoices.InvoiceStateIssuing:     "Issuing",
+		invoices.InvoiceStateIssued:      "Issued",
+		invoices.InvoiceStateRejected:    "Rejected",
+		invoices.InvoiceStateVoided:      "Voided",
+		invoices.InvoiceStateShipped:     "Shipped",
+		invoices.InvoiceStateRedReversed: "Red-reversed",
+	}
+
+	fmt.Printf("\nInvoice Applications:\n")
+	for i, r := range results {
+		fmt.Printf("  [%d] Apply No: %s\n", i+1, r.InvoiceApplyNo)
+		fmt.Printf("      Amount: %s fen\n", r.Amt)
+		fmt.Printf("      State: %s (%d)\n", stateNames[r.State], r.State)
+		for _, f := range r.InvoiceFiles() {
+			fmt.Printf("      File: %s\n", f)
+		}
+	}
+}
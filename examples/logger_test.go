@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vogo/vservicesharesdk/accounts"
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+func TestLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resCode":"6049","resMsg":"未查询到符合条件的记录"}`))
+	}))
+	defer server.Close()
+
+	// Route the client logs into a JSON handler
+	var buf bytes.Buffer
+	client := createLocalClient(t, server.URL, func(config *cores.Config) {
+		config.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	})
+
+	_, _ = accounts.NewService(client).BalanceQuery(&accounts.BalanceQueryRequest{ProviderID: 1})
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("unexpected log output %q: %v", buf.String(), err)
+	}
+	for _, key := range []string{"merchantId", "funCode", "reqId", "httpStatus", "latency", "resCode"} {
+		if _, ok := record[key]; !ok {
+			t.Fatalf("missing attribute %s in %v", key, record)
+		}
+	}
+	fmt.Print(buf.String())
+}
+
+func TestRedactPolicy(t *testing.T) {
+	policy := cores.DefaultRedactPolicy()
+
+	redacted := policy.Redact(`{"merBatchId":"BATCH_001","payItems":[{"merOrderId":"ORDER_001","amt":100,` +
+		`"payeeName":"张三","payeeAcc":"6222021234567890123","idCard":"110101199001011234","mobile":"13800138000"}]}`)
+	fmt.Println(redacted)
+
+	for _, leaked := range []string{"张三", "6222021234567890123", "110101199001011234", "13800138000"} {
+		if strings.Contains(redacted, leaked) {
+			t.Fatalf("%s leaked in %s", leaked, redacted)
+		}
+	}
+	for _, kept := range []string{`"merBatchId":"BATCH_001"`, `"idCard":"110****1234"`, `"payeeName":"张****"`} {
+		if !strings.Contains(redacted, kept) {
+			t.Fatalf("%s missing in %s", kept, redacted)
+		}
+	}
+
+	// Plain text, e.g. a gateway error page, has its long digit runs masked
+	redacted = policy.Redact(`bad gateway: payee 11010119900101123X account 6222021234567890123 amt 100`)
+	fmt.Println(redacted)
+	if strings.Contains(redacted, "11010119900101123X") || strings.Contains(redacted, "6222021234567890123") ||
+		!strings.Contains(redacted, "110****123X") || !strings.Contains(redacted, "amt 100") {
+		t.Fatalf("plain text not masked: %s", redacted)
+	}
+
+	// Sub-account numbers are masked
+	if redacted = policy.Redact(`{"subAccNo":"9558801234567890"}`); strings.Contains(redacted, "9558801234567890") {
+		t.Fatalf("subAccNo leaked in %s", redacted)
+	}
+
+	// Large blobs are truncated
+	redacted = policy.Redact(`{"resData":"` + strings.Repeat("ab", 1000) + `"}`)
+	if len(redacted) > cores.DefaultRedactMaxLen+64 {
+		t.Fatalf("blob not truncated: %d chars", len(redacted))
+	}
+}
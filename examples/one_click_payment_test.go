@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/payments"
+)
+
+func TestOneClickPayment(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create payments service
+	paymentService := payments.NewService(client)
+
+	// Generate unique batch ID
+	batchId := fmt.Sprintf("ONECLICK_%d", time.Now().Unix())
+
+	req := &payments.PaymentRequest{
+		MerBatchId: batchId,
+		PayItems: []payments.PaymentItem{
+			{
+				MerOrderId:  fmt.Sprintf("ORDER_%d_001", time.Now().Unix()),
+				Amt:         10202, // CNY in fen
+				PayeeName:   vos.EnvString("SS_FREELANCER_NAME"),
+				PayeeAcc:    vos.EnvString("SS_FREELANCER_CARD_NO"),
+				IdCard:      vos.EnvString("SS_FREELANCER_ID_CARD"),
+				Mobile:      vos.EnvString("SS_FREELANCER_MOBILE"),
+				PaymentType: cores.PaymentTypeBankCard,
+				Memo:        "Freelance payment",
+			},
+		},
+		TaskId:     vos.EnvInt64("SS_TASK_ID"),
+		ProviderId: vos.EnvInt64("SS_PROVIDER_ID"),
+	}
+
+	// Submit one-click payment, falling back to the standard payment when not enabled
+	oneClick := true
+	resp, err := paymentService.OneClickPayment(req)
+	if errors.Is(err, cores.ErrApiOneClickPaymentNotEnabled) {
+		fmt.Printf("One-click payment not enabled, falling back to standard payment\n")
+		oneClick = false
+		resp, err = paymentService.Payment(req)
+	}
+	if err != nil {
+		log.Fatalf("failed to submit payment | err: %v", err)
+	}
+
+	fmt.Printf("Payment Submitted:\n")
+	fmt.Printf("  Batch ID: %s\n", resp.MerBatchId)
+	fmt.Printf("  Success Count: %d\n", resp.SuccessNum)
+	fmt.Printf("  Failure Count: %d\n", resp.FailureNum)
+
+	// Wait a bit then query the batch with the matching query interface
+	time.Sleep(2 * time.Second)
+
+	queryReq := &payments.PaymentQueryRequest{MerBatchId: batchId}
+	var queryResp *payments.PaymentBatchResult
+	if oneClick {
+		queryResp, err = paymentService.OneClickPaymentQuery(queryReq)
+	} else {
+		queryResp, err = paymentService.PaymentQuery(queryReq)
+	}
+	if err != nil {
+		log.Fatalf("failed to query payment | err: %v", err)
+	}
+
+	for i, item := range queryResp.QueryItems {
+		fmt.Printf("  [%d] Order: %s, Platform Order: %d, State: %d\n", i+1, item.MerOrderId, item.OrderNo, item.State)
+	}
+}
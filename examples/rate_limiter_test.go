@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/accounts"
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+func TestRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resCode":"6049","resMsg":"未查询到符合条件的记录"}`))
+	}))
+	defer server.Close()
+
+	// Clients of the same merchant share the limiter
+	limiter := cores.SharedRateLimiter(server.URL, "M0001")
+	if cores.SharedRateLimiter("https://prod.example.com", "M0001") == limiter {
+		t.Fatal("gateways share the rate limiter")
+	}
+	limiter.SetRate(cores.FunCodeBalanceQuery, 20, 1)
+	client := createLocalClient(t, server.URL, func(config *cores.Config) {
+		config.RateLimiter = limiter
+	})
+
+	accountService := accounts.NewService(client)
+	req := &accounts.BalanceQueryRequest{ProviderID: 1}
+
+	// Blocking mode spaces the calls at 20 per second
+	start := time.Now()
+	for range 5 {
+		_, _ = accountService.BalanceQueryContext(context.Background(), req)
+	}
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Fatalf("calls were not limited: %s", elapsed)
+	}
+	fmt.Printf("5 calls took %s\n", time.Since(start))
+
+	// Fail fast mode returns at once when no token is left
+	client = createLocalClient(t, server.URL, func(config *cores.Config) {
+		config.RateLimiter = limiter
+		config.RateLimitMode = cores.RateLimitFailFast
+	})
+	accountService = accounts.NewService(client)
+	limited := 0
+	for range 3 {
+		if _, err := accountService.BalanceQuery(req); errors.Is(err, cores.ErrRateLimited) {
+			limited++
+		}
+	}
+	if limited == 0 {
+		t.Fatalf("expected rate limited calls")
+	}
+	fmt.Printf("%d of 3 calls rate limited\n", limited)
+}
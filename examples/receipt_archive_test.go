@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/payments"
+)
+
+func TestReceiptArchive(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create payments service
+	paymentService := payments.NewService(client)
+
+	merBatchId := vos.EnvString("SS_RECEIPT_BATCH_ID")
+	merOrderId := vos.EnvString("SS_RECEIPT_ORDER_ID")
+
+	// Query receipt
+	receipt, err := paymentService.QueryReceipt(merBatchId, merOrderId)
+	if err != nil {
+		log.Fatalf("failed to query receipt | err: %v", err)
+	}
+	if !receipt.Available {
+		fmt.Printf("Receipt of order %s is not yet available (T+2)\n", merOrderId)
+		return
+	}
+	fmt.Printf("Receipt URL (valid for 30 days): %s\n", receipt.ReceiptUrl)
+
+	// Archive receipt to a local directory
+	archiver := payments.NewReceiptArchiver(paymentService, payments.NewLocalReceiptStorage(t.TempDir()))
+	result, err := archiver.Archive(merBatchId, merOrderId)
+	if err != nil {
+		log.Fatalf("failed to archive receipt | err: %v", err)
+	}
+
+	fmt.Printf("Receipt archived: %v, file: %s\n", result.Archived, result.Name)
+}
@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/recharges"
+)
+
+func TestRechargeQuery(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create recharges service
+	rechargeService := recharges.NewService(client)
+
+	// Query rechargeable amount
+	amount, err := rechargeService.QueryRechargeAmount(&recharges.RechargeAmountQueryRequest{
+		SubAccNo: vos.EnvString("SS_SUB_ACC_NO"),
+	})
+	if err != nil {
+		log.Fatalf("failed to query recharge amount | err: %v", err)
+	}
+
+	fmt.Printf("Recharge Amount Query Result:\n")
+	fmt.Printf("  Sub Account: %s\n", amount.SubAccNo)
+	fmt.Printf("  Sub Account Balance: %s fen\n", amount.SubAccAmt)
+	fmt.Printf("  Available: %d fen (%.2f CNY)\n", amount.AvailableRechargeAmt, float64(amount.AvailableRechargeAmt)/100)
+
+	enterpriseOrderNo := vos.EnvString("SS_RECHARGE_ORDER_NO")
+	if enterpriseOrderNo == "" {
+		return
+	}
+
+	// Query recharge result
+	result, err := rechargeService.QueryRechargeResult(&recharges.RechargeResultQueryRequest{
+		EnterpriseOrderNo: enterpriseOrderNo,
+	})
+	if err != nil {
+		log.Fatalf("failed to query recharge result | err: %v", err)
+	}
+
+	fmt.Printf("\nRecharge Records:\n")
+	for i, r := range result.RechargeRecordList {
+		fmt.Printf("  [%d] Order: %s (platform: %s)\n", i+1, r.EnterpriseOrderNo, r.OrderNo)
+		fmt.Printf("      State: %s\n", r.RechargeState)
+		fmt.Printf("      Recharge: %d fen, Fee: %d fen, Credited: %d fen\n", r.RechargeAmt, r.FeeAmt, r.AccountingAmt)
+		if r.ErrorMsg != "" {
+			fmt.Printf("      Error: %s\n", r.ErrorMsg)
+		}
+	}
+}
+
+func TestRechargeRecords(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create recharges service
+	rechargeService := recharges.NewService(client)
+
+	// Query the last quarter, split into 31-day windows automatically
+	end := time.Now()
+	start := end.AddDate(0, -3, 0)
+	records, err := rechargeService.QueryRechargeRecords(vos.EnvInt64("SS_PROVIDER_ID"), start, end)
+	if err != nil {
+		log.Fatalf("failed to query recharge records | err: %v", err)
+	}
+
+	fmt.Printf("Recharge Records (%s ~ %s): %d\n", start.Format(time.DateOnly), end.Format(time.DateOnly), len(records))
+	for i, r := range records {
+		fmt.Printf("  [%d] Order: %s (platform: %s)\n", i+1, r.EnterpriseOrderNo, r.OrderNo)
+		fmt.Printf("      Recharge: %d fen, Credited: %d fen\n", r.RechargeAmt, r.AccountingAmt)
+		fmt.Printf("      Payer: %s %s\n", r.PayBankName, r.PayBankNo)
+		fmt.Printf("      Time: %s\n", r.CreateTime)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/payments"
+	"github.com/vogo/vservicesharesdk/reconciliation"
+)
+
+// memoryLedger is a ledger backed by in-memory entries grouped by batch.
+type memoryLedger map[string][]reconciliation.LedgerEntry
+
+func (l memoryLedger) LedgerEntries(ctx context.Context, merBatchId string) ([]reconciliation.LedgerEntry, error) {
+	return l[merBatchId], nil
+}
+
+func TestReconcile(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	merBatchId := vos.EnvString("SS_RECONCILE_BATCH_ID")
+	if merBatchId == "" {
+		t.Skip("SS_RECONCILE_BATCH_ID not set")
+	}
+
+	// Ledger recording a single order of the batch
+	ledger := memoryLedger{
+		merBatchId: {
+			{MerBatchId: merBatchId, MerOrderId: vos.EnvString("SS_RECONCILE_ORDER_ID"), Amt: vos.EnvInt64("SS_RECONCILE_AMOUNT")},
+		},
+	}
+
+	reconciler := reconciliation.NewReconciler(payments.NewService(client), ledger)
+	report, err := reconciler.Reconcile([]string{merBatchId})
+	if err != nil {
+		log.Fatalf("failed to reconcile | err: %v", err)
+	}
+
+	fmt.Printf("Reconciled %d batches, %d matched, %d diffs\n", report.Batches, report.Matched, len(report.Diffs))
+	if err := report.WriteCSV(os.Stdout); err != nil {
+		log.Fatalf("failed to write report | err: %v", err)
+	}
+}
+
+func TestCompareBatch(t *testing.T) {
+	local := []reconciliation.LedgerEntry{
+		{MerBatchId: "B1", MerOrderId: "O1", Amt: 10000, Fee: 600},
+		{MerBatchId: "B1", MerOrderId: "O2", Amt: 20000, Fee: 1200},
+		{MerBatchId: "B1", MerOrderId: "O3", Amt: 30000},
+		{MerBatchId: "B1", MerOrderId: "O5", Amt: 50000},
+		{MerBatchId: "B1", MerOrderId: "O6", OrderNo: 99, Amt: 60000},
+		{MerBatchId: "B1", MerOrderId: "O7", Amt: 70000},
+	}
+	remote := []payments.PaymentResult{
+		{PaymentBaseResult: payments.PaymentBaseResult{MerOrderId: "O1", Amt: 10000, Fee: 600, State: payments.PaymentStateSuccess}, OrderNo: 1},
+		{PaymentBaseResult: payments.PaymentBaseResult{MerOrderId: "O2", Amt: 20000, Fee: 1000, VaTax: 50, State: payments.PaymentStateSuccess}, OrderNo: 2},
+		{PaymentBaseResult: payments.PaymentBaseResult{MerOrderId: "O4", Amt: 40000, State: payments.PaymentStateSuccess}, OrderNo: 4},
+		{PaymentBaseResult: payments.PaymentBaseResult{MerOrderId: "O5", Amt: 50000, State: payments.PaymentStateFailed}, OrderNo: 5},
+		{PaymentBaseResult: payments.PaymentBaseResult{MerOrderId: "O6", Amt: 60000, State: payments.PaymentStateSuccess}, OrderNo: 6},
+		{PaymentBaseResult: payments.PaymentBaseResult{MerOrderId: "O7", Amt: 70000, State: payments.PaymentStateProcessing}, OrderNo: 7},
+		{PaymentBaseResult: payments.PaymentBaseResult{MerOrderId: "O8", Amt: 80000, State: payments.PaymentStatePendingConfirm}, OrderNo: 8},
+	}
+
+	diffs := reconciliation.CompareBatch("B1", local, remote)
+
+	expected := []struct {
+		diffType   reconciliation.DiffType
+		merOrderId string
+		field      string
+	}{
+		{reconciliation.DiffFee, "O2", "fee"},
+		{reconciliation.DiffTax, "O2", "vaTax"},
+		{reconciliation.DiffMissingRemote, "O3", ""},
+		{reconciliation.DiffMissingRemote, "O5", ""},
+		{reconciliation.DiffMissingRemote, "O6", ""}, // the ledger OrderNo conflicts with the platform
+		{reconciliation.DiffPending, "O7", ""},       // in flight orders are neither matched nor missing
+		{reconciliation.DiffMissingLocal, "O4", ""},
+		{reconciliation.DiffMissingLocal, "O6", ""},
+		{reconciliation.DiffPending, "O8", ""},
+	}
+	if len(diffs) != len(expected) {
+		t.Fatalf("unexpected diffs: %+v", diffs)
+	}
+	for i, e := range expected {
+		d := diffs[i]
+		if d.Type != e.diffType || d.MerOrderId != e.merOrderId || d.Field != e.field {
+			t.Fatalf("unexpected diff %d: %+v", i, d)
+		}
+	}
+
+	report := &reconciliation.Report{Batches: 1, Matched: 1, Diffs: diffs}
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("failed to write csv: %v", err)
+	}
+	fmt.Print(buf.String())
+
+	// Write errors are returned
+	if err := report.WriteCSV(failingWriter{}); err == nil {
+		t.Fatal("expected write error")
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
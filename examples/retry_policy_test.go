@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/accounts"
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/freelancers"
+	"github.com/vogo/vservicesharesdk/payments"
+)
+
+// createLocalClient creates a client with generated keys calling the given URL,
+// letting configure adjust the configuration if not nil.
+func createLocalClient(t *testing.T, url string, configure func(config *cores.Config)) *cores.Client {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := cores.NewConfig(
+		url,
+		"M0001",
+		"12345678",
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+		0,
+	)
+	if configure != nil {
+		configure(config)
+	}
+
+	client, err := cores.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestRetryPolicy(t *testing.T) {
+	// Gateway answering every request with the communication error 6000
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		_, _ = w.Write([]byte(`{"resCode":"6000","resMsg":"当前请求处理未明，请核实"}`))
+	}))
+	defer server.Close()
+
+	policy := cores.DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	client := createLocalClient(t, server.URL, func(config *cores.Config) {
+		config.RetryPolicy = policy
+	})
+
+	// Queries are retried up to MaxAttempts
+	_, err := accounts.NewService(client).BalanceQuery(&accounts.BalanceQueryRequest{ProviderID: 1})
+	if !errors.Is(err, cores.ErrApiUnknown) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := attempts.Swap(0); n != 3 {
+		t.Fatalf("expected 3 attempts, got %d", n)
+	}
+	fmt.Printf("Balance query attempts: 3, err: %v\n", err)
+
+	payment := func(client *cores.Client, merBatchId string) {
+		_, _ = payments.NewService(client).Payment(&payments.PaymentRequest{
+			MerBatchId: merBatchId,
+			ProviderId: 1,
+			TaskId:     1,
+			PayItems: []payments.PaymentItem{
+				{MerOrderId: merBatchId + "_1", Amt: 100, PayeeName: "张三", PayeeAcc: "6222021234567890123", IdCard: "110101199001011234", Mobile: "13800138000"},
+			},
+		})
+	}
+
+	// Payouts are not retried by default
+	payment(client, "BATCH_001")
+	if n := attempts.Swap(0); n != 1 {
+		t.Fatalf("expected 1 payout attempt, got %d", n)
+	}
+
+	// Writes without an idempotency key are never retried
+	_, _ = freelancers.NewService(client).SignContract(&freelancers.SignContractRequest{
+		Name: "张三", CardNo: "6222021234567890123", IdCard: "110101199001011234", Mobile: "13800138000",
+		PaymentType: cores.PaymentTypeBankCard, ProviderId: 1, IdCardPic1: "ffd8", IdCardPic2: "ffd8",
+	})
+	if n := attempts.Swap(0); n != 1 {
+		t.Fatalf("expected 1 sign attempt, got %d", n)
+	}
+
+	// Payouts opted in are retried with the same merBatchId and merOrderId
+	client = createLocalClient(t, server.URL, func(config *cores.Config) {
+		config.RetryPolicy = policy
+		config.RetryPolicy.RetryWrites = []string{cores.FunCodePayment.Code}
+	})
+	payment(client, "BATCH_002")
+	if n := attempts.Swap(0); n != 3 {
+		t.Fatalf("expected 3 payout attempts, got %d", n)
+	}
+}
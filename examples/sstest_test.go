@@ -0,0 +1,408 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/accounts"
+	"github.com/vogo/vservicesharesdk/callbacks"
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/freelancers"
+	"github.com/vogo/vservicesharesdk/payments"
+	"github.com/vogo/vservicesharesdk/sstest"
+)
+
+// createFakeClient creates a client of the fake gateway, letting configure adjust the configuration if not nil.
+func createFakeClient(t *testing.T, gateway *sstest.Server, configure func(config *cores.Config)) *cores.Client {
+	config := gateway.Config()
+	if configure != nil {
+		configure(config)
+	}
+
+	client, err := cores.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestFakeGateway(t *testing.T) {
+	gateway := sstest.NewServer()
+	defer gateway.Close()
+
+	client := createFakeClient(t, gateway, nil)
+	const providerId = 1
+
+	// Notification receiver running the hooks once per key
+	var mu sync.Mutex
+	paid := make(map[string]payments.PaymentState)
+	paymentRuns := 0
+	signed := 0
+	handler := callbacks.NewHandler(client)
+	handler.Store = callbacks.NewMemoryStore(time.Hour)
+	handler.OnPayment = func(ctx context.Context, result *payments.PaymentResult) error {
+		mu.Lock()
+		defer mu.Unlock()
+		paid[result.MerOrderId] = result.State
+		paymentRuns++
+		return nil
+	}
+	handler.OnSign = func(ctx context.Context, result *freelancers.SignContractResult) error {
+		mu.Lock()
+		defer mu.Unlock()
+		signed++
+		return nil
+	}
+	receiver := httptest.NewServer(handler)
+	defer receiver.Close()
+
+	// Balance
+	balance, err := accounts.NewService(client).BalanceQuery(&accounts.BalanceQueryRequest{ProviderID: providerId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("balance: %d\n", balance.Balance)
+
+	// Contract signing, query and termination
+	freelancerService := freelancers.NewService(client)
+	_, err = freelancerService.SignContract(&freelancers.SignContractRequest{
+		Name:        "张三",
+		CardNo:      "6222021234567890123",
+		IdCard:      "110101199001011234",
+		Mobile:      "13800138000",
+		PaymentType: cores.PaymentTypeBankCard,
+		ProviderId:  providerId,
+		IdCardPic1:  "ffd8",
+		IdCardPic2:  "ffd8",
+		NotifyUrl:   receiver.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract, err := freelancerService.SignContractQuery(&freelancers.SignQueryRequest{
+		Name:       "张三",
+		IdCard:     "110101199001011234",
+		Mobile:     "13800138000",
+		ProviderId: providerId,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contract.State != freelancers.SignStateSigned {
+		t.Fatalf("expected signed, got %d", contract.State)
+	}
+	cancelled, err := freelancerService.CancelContract(&freelancers.CancelContractRequest{
+		UserName: "张三",
+		IdcardNo: "110101199001011234",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.State != freelancers.CancelContractStateSuccess {
+		t.Fatalf("expected cancelled, got %s", cancelled.State)
+	}
+
+	// Payment amounts ending in 0 stay processing, odd ones fail and even ones succeed
+	paymentService := payments.NewService(client)
+	item := func(merOrderId string, amt int64) payments.PaymentItem {
+		return payments.PaymentItem{
+			MerOrderId:  merOrderId,
+			Amt:         amt,
+			PayeeName:   "张三",
+			PayeeAcc:    "6222021234567890123",
+			IdCard:      "110101199001011234",
+			Mobile:      "13800138000",
+			PaymentType: cores.PaymentTypeBankCard,
+			NotifyUrl:   receiver.URL,
+		}
+	}
+	resp, err := paymentService.Payment(&payments.PaymentRequest{
+		MerBatchId: "B001",
+		ProviderId: providerId,
+		PayItems:   []payments.PaymentItem{item("O1", 1000), item("O2", 1001), item("O3", 1002)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("payment accepted: %d\n", resp.SuccessNum)
+
+	result, err := paymentService.PaymentQuery(&payments.PaymentQueryRequest{MerBatchId: "B001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []payments.PaymentState{payments.PaymentStateProcessing, payments.PaymentStateFailed, payments.PaymentStateSuccess}
+	for i, order := range result.QueryItems {
+		fmt.Printf("  %s amt=%d state=%d\n", order.MerOrderId, order.Amt, order.State)
+		if order.State != expected[i] {
+			t.Fatalf("order %s: expected state %d, got %d", order.MerOrderId, expected[i], order.State)
+		}
+	}
+
+	// Settle the processing order, then resend its notification as the platform does
+	if err := gateway.SetOrderState("O1", payments.PaymentStateSuccess); err != nil {
+		t.Fatal(err)
+	}
+	acked, err := gateway.Notify(context.Background(), receiver.URL, cores.FunCodePayment, result.QueryItems[2])
+	if err != nil || !acked {
+		t.Fatalf("resend not acknowledged: %v", err)
+	}
+
+	for _, n := range gateway.Notifications() {
+		fmt.Printf("notification: funCode=%s acked=%t\n", n.FunCode, n.Acked)
+		if !n.Acked {
+			t.Fatalf("notification not acknowledged: %v", n.Err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if signed != 1 || paymentRuns != 3 || paid["O1"] != payments.PaymentStateSuccess || paid["O2"] != payments.PaymentStateFailed {
+		t.Fatalf("unexpected notifications: signed=%d paymentRuns=%d paid=%v", signed, paymentRuns, paid)
+	}
+}
+
+func TestFakeGatewayFaults(t *testing.T) {
+	gateway := sstest.NewServer()
+	defer gateway.Close()
+
+	const providerId = 1
+	request := func(merBatchId string) *payments.PaymentRequest {
+		return &payments.PaymentRequest{
+			MerBatchId: merBatchId,
+			ProviderId: providerId,
+			PayItems: []payments.PaymentItem{{
+				MerOrderId:  merBatchId + "-1",
+				Amt:         1002,
+				PayeeName:   "张三",
+				PayeeAcc:    "6222021234567890123",
+				IdCard:      "110101199001011234",
+				Mobile:      "13800138000",
+				PaymentType: cores.PaymentTypeBankCard,
+			}},
+		}
+	}
+
+	// 6042 is retried by the retry policy
+	client := createFakeClient(t, gateway, func(config *cores.Config) {
+		config.RetryPolicy = cores.DefaultRetryPolicy()
+		config.RetryPolicy.BaseDelay = time.Millisecond
+	})
+	gateway.Inject(cores.FunCodeBalanceQuery, sstest.TooFrequent(), sstest.TooFrequent())
+	if _, err := accounts.NewService(client).BalanceQuery(&accounts.BalanceQueryRequest{ProviderID: providerId}); err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("balance query requests: %d\n", gateway.Requests(cores.FunCodeBalanceQuery))
+	if gateway.Requests(cores.FunCodeBalanceQuery) != 3 {
+		t.Fatalf("expected 3 balance query requests, got %d", gateway.Requests(cores.FunCodeBalanceQuery))
+	}
+
+	// Payouts are not retried by default, leaving an ambiguous 6000 to the caller
+	gateway.Inject(cores.FunCodePayment, sstest.Unknown())
+	_, err := payments.NewService(client).Payment(request("B000"))
+	fmt.Printf("payment after 6000: %v\n", err)
+	if !errors.Is(err, cores.ErrApiUnknown) {
+		t.Fatalf("expected unknown result, got %v", err)
+	}
+
+	// An opted-in retry after an ambiguous 6000 is rejected as a duplicate and resolved by querying
+	client = createFakeClient(t, gateway, func(config *cores.Config) {
+		config.RetryPolicy = cores.DefaultRetryPolicy()
+		config.RetryPolicy.BaseDelay = time.Millisecond
+		config.RetryPolicy.RetryWrites = []string{cores.FunCodePayment.Code}
+	})
+	gateway.Inject(cores.FunCodePayment, sstest.Unknown())
+	resp, err := payments.NewService(client).Payment(request("B001"))
+	if err != nil {
+		t.Fatalf("expected resolved payment, got %v", err)
+	}
+	fmt.Printf("payment after 6000 and retry: %d orders, %s\n", resp.SuccessNum, resp.PayResultList[0].OrderNo)
+	if resp.SuccessNum != 1 || resp.PayResultList[0].MerOrderId != "B001-1" {
+		t.Fatalf("unexpected resolved payment: %+v", resp)
+	}
+
+	// A duplicate after a 6042, which was not processed, is a reused batch number
+	if _, err := payments.NewService(client).Payment(request("B003")); err != nil {
+		t.Fatal(err)
+	}
+	gateway.Inject(cores.FunCodePayment, sstest.TooFrequent())
+	_, err = payments.NewService(client).Payment(request("B003"))
+	fmt.Printf("reused batch after 6042: %v\n", err)
+	if !errors.Is(err, cores.ErrApiBatchNoDuplicate) || errors.Is(err, cores.ErrRetriedDuplicate) {
+		t.Fatalf("expected batch duplicate, got %v", err)
+	}
+
+	// A duplicate after a 6000 is not resolved to a batch holding other orders
+	reused := request("B003")
+	reused.PayItems[0].MerOrderId = "B003-2"
+	gateway.Inject(cores.FunCodePayment, sstest.Unknown())
+	if _, err = payments.NewService(client).Payment(reused); !errors.Is(err, cores.ErrRetriedDuplicate) {
+		t.Fatalf("expected retried duplicate, got %v", err)
+	}
+
+	// A timeout leaves the outcome to be resolved by querying
+	client = createFakeClient(t, gateway, func(config *cores.Config) {
+		config.Timeout = 100 * time.Millisecond
+	})
+	paymentService := payments.NewService(client)
+	gateway.Inject(cores.FunCodePayment, sstest.Timeout(time.Second))
+	_, err = paymentService.Payment(request("B002"))
+	fmt.Printf("payment after timeout: %v\n", err)
+	if !errors.Is(err, cores.ErrRequestFailed) {
+		t.Fatalf("expected request failure, got %v", err)
+	}
+
+	for _, merBatchId := range []string{"B000", "B001", "B002"} {
+		result, err := paymentService.PaymentQuery(&payments.PaymentQueryRequest{MerBatchId: merBatchId})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.QueryItems) != 1 || result.QueryItems[0].State != payments.PaymentStateSuccess {
+			t.Fatalf("batch %s not paid: %+v", merBatchId, result.QueryItems)
+		}
+	}
+}
+
+func TestFakeGatewayQuotaCheck(t *testing.T) {
+	gateway := sstest.NewServer()
+	defer gateway.Close()
+
+	// The second payee has not signed, so the platform rejects the quota lookup
+	gateway.Handle(cores.FunCodeQuotaQuery, func(ctx context.Context, reqData []byte) (any, error) {
+		var req freelancers.QuotaQueryRequest
+		if err := json.Unmarshal(reqData, &req); err != nil {
+			return nil, cores.ErrApiParamError
+		}
+		if req.IdCard != "110101199001011234" {
+			return nil, cores.ErrApiNotSignedWithProvider
+		}
+		return &freelancers.QuotaResult{ProviderId: req.ProviderId, Name: req.Name, IdCard: req.IdCard, Balance: 100000}, nil
+	})
+
+	client := createFakeClient(t, gateway, nil)
+	paymentService := payments.NewService(client)
+	item := func(merOrderId, idCard string) payments.PaymentItem {
+		return payments.PaymentItem{
+			MerOrderId:  merOrderId,
+			Amt:         1000,
+			PayeeName:   "张三",
+			PayeeAcc:    "6222021234567890123",
+			IdCard:      idCard,
+			Mobile:      "13800138000",
+			PaymentType: cores.PaymentTypeBankCard,
+		}
+	}
+	req := &payments.PaymentRequest{
+		MerBatchId: "Q001",
+		ProviderId: 1,
+		PayItems:   []payments.PaymentItem{item("Q001-1", "110101199001011234"), item("Q001-2", "110101199001015678")},
+		QuotaCheck: payments.QuotaCheckDrop,
+	}
+
+	// Drop mode drops the payee whose quota lookup is rejected
+	resp, err := paymentService.Payment(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("dropped: %+v\n", resp.DroppedItems)
+	if resp.SuccessNum != 1 || len(resp.DroppedItems) != 1 || resp.DroppedItems[0].MerOrderId != "Q001-2" ||
+		!errors.Is(resp.DroppedItems[0].Err, cores.ErrApiNotSignedWithProvider) {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	// Flag mode fails on the rejected lookup
+	req.MerBatchId = "Q002"
+	req.QuotaCheck = payments.QuotaCheckFlag
+	if _, err := paymentService.OneClickPayment(req); !errors.Is(err, cores.ErrApiNotSignedWithProvider) {
+		t.Fatalf("expected quota lookup error, got %v", err)
+	}
+
+	// Methods not checking quotas reject the option
+	if _, err := paymentService.UploadBatch(req); err == nil {
+		t.Fatal("expected quotaCheck rejected by batch upload")
+	}
+	if _, err := paymentService.TrialCalculatePayment(req); err == nil {
+		t.Fatal("expected quotaCheck rejected by trial calculation")
+	}
+}
+
+func TestFakeGatewayReceiptArchive(t *testing.T) {
+	gateway := sstest.NewServer()
+	defer gateway.Close()
+
+	files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large.pdf" {
+			w.Write(bytes.Repeat([]byte{'x'}, payments.MaxReceiptSize+1))
+			return
+		}
+		w.Write([]byte("%PDF-1.4"))
+	}))
+	defer files.Close()
+
+	gateway.Handle(cores.FunCodeReceiptQuery, func(ctx context.Context, reqData []byte) (any, error) {
+		var req payments.ReceiptQueryRequest
+		if err := json.Unmarshal(reqData, &req); err != nil {
+			return nil, cores.ErrApiParamError
+		}
+		name := "/receipt.pdf"
+		if req.MerOrderId == "R001-2" {
+			name = "/large.pdf"
+		}
+		return &payments.ReceiptResult{MerBatchId: req.MerBatchId, MerOrderId: req.MerOrderId, ReceiptUrl: files.URL + name}, nil
+	})
+
+	client := createFakeClient(t, gateway, nil)
+	dir := t.TempDir()
+	archiver := payments.NewReceiptArchiver(payments.NewService(client), payments.NewLocalReceiptStorage(dir))
+
+	result, err := archiver.Archive("R001", "R001-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Archived || result.Name != "R001-1.pdf" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	// Stored receipts are not queried again
+	result, err = archiver.Archive("R001", "R001-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Archived || gateway.Requests(cores.FunCodeReceiptQuery) != 1 {
+		t.Fatalf("expected stored receipt without query, got %+v after %d queries", result, gateway.Requests(cores.FunCodeReceiptQuery))
+	}
+
+	// Oversized receipts are rejected without leaving a file
+	if _, err := archiver.Archive("R001", "R001-2"); err == nil {
+		t.Fatal("expected oversized receipt rejected")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "R001-2.pdf")); !os.IsNotExist(err) {
+		t.Fatalf("expected no stored file, got %v", err)
+	}
+}
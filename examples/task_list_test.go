@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/sstest"
+	"github.com/vogo/vservicesharesdk/tasks"
+)
+
+func TestListTasks(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create tasks service
+	taskService := tasks.NewService(client)
+
+	list, err := taskService.ListTasks()
+	if err != nil {
+		log.Fatalf("failed to list tasks | err: %v", err)
+	}
+
+	fmt.Printf("Tasks: %d\n", len(list))
+	for i, task := range list {
+		fmt.Printf("  [%d] ID: %d, Name: %s, Status: %s, Active: %t, Dates: %s ~ %s\n",
+			i+1, task.TaskId, task.TaskName, task.TaskStatus, task.TaskStatus.IsActive(), task.StartTime, task.EndTime)
+	}
+
+	// Check the configured task before paying
+	cache := tasks.NewTaskCache(taskService, time.Minute)
+	err = cache.CheckTask(vos.EnvInt64("SS_TASK_ID"), time.Now())
+	switch {
+	case err == nil:
+		fmt.Printf("Task is active\n")
+	case errors.Is(err, cores.ErrApiTaskNotFound):
+		fmt.Printf("Task not found: %v\n", err)
+	case errors.Is(err, cores.ErrApiTaskStatusError):
+		fmt.Printf("Task not usable: %v\n", err)
+	default:
+		log.Fatalf("failed to check task | err: %v", err)
+	}
+}
+
+func TestTaskCacheConcurrentLoad(t *testing.T) {
+	gateway := sstest.NewServer()
+	defer gateway.Close()
+
+	var empty atomic.Bool
+	gateway.Handle(cores.FunCodeTaskList, func(ctx context.Context, reqData []byte) (any, error) {
+		if empty.Load() {
+			return nil, nil
+		}
+		time.Sleep(100 * time.Millisecond)
+		return []tasks.Task{{TaskId: 1, TaskName: "design", TaskStatus: tasks.TaskStatusConduct}}, nil
+	})
+	taskService := tasks.NewService(createFakeClient(t, gateway, nil))
+
+	// Concurrent readers of an expired cache share a single slow load
+	cache := tasks.NewTaskCache(taskService, time.Minute)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := cache.CheckTask(1, time.Now()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("task list requests: %d\n", gateway.Requests(cores.FunCodeTaskList))
+	if gateway.Requests(cores.FunCodeTaskList) != 1 {
+		t.Fatalf("expected 1 task list request, got %d", gateway.Requests(cores.FunCodeTaskList))
+	}
+
+	// An empty response is an error like in every other service
+	empty.Store(true)
+	if _, err := taskService.ListTasks(); err == nil {
+		t.Fatal("expected empty response error")
+	}
+}
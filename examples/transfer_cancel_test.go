@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/payments"
+)
+
+func TestCancelTransfer(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create payments service
+	paymentService := payments.NewService(client)
+
+	// Cancel a WeChat transfer waiting for user confirmation
+	resp, err := paymentService.CancelTransfer(&payments.CancelTransferRequest{
+		MerBatchId: vos.EnvString("SS_CANCEL_BATCH_ID"),
+		MerOrderId: vos.EnvString("SS_CANCEL_ORDER_ID"),
+	})
+	if errors.Is(err, cores.ErrApiOrderCannotBeCancelled) {
+		fmt.Printf("Order is not pending confirmation and cannot be cancelled\n")
+		return
+	}
+	if err != nil {
+		log.Fatalf("failed to cancel transfer | err: %v", err)
+	}
+
+	fmt.Printf("Transfer Cancellation Accepted:\n")
+	fmt.Printf("  Batch ID: %s\n", resp.MerBatchId)
+	fmt.Printf("  Order: %s\n", resp.MerOrderId)
+	fmt.Printf("  Platform Order: %d\n", resp.OrderNo)
+	fmt.Printf("\nNote: Use PaymentQuery to confirm the cancelled state.\n")
+}
@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/vogo/vogo/vos"
+	"github.com/vogo/vservicesharesdk/payments"
+)
+
+func TestTrialCalculate(t *testing.T) {
+	// Create client from environment variables
+	client := CreateClient(t)
+
+	// Create payments service
+	paymentService := payments.NewService(client)
+
+	for _, reverse := range []bool{false, true} {
+		// Forward calculates net from gross, reverse calculates gross from net
+		results, err := paymentService.TrialCalculate(&payments.TrialCalculateRequest{
+			ProviderId: vos.EnvInt64("SS_PROVIDER_ID"),
+			IfReverse:  reverse,
+			UserList: []payments.TrialUser{
+				{
+					Name:     vos.EnvString("SS_FREELANCER_NAME"),
+					IdCardNo: vos.EnvString("SS_FREELANCER_ID_CARD"),
+					Amt:      1000000, // 10000 CNY in fen
+				},
+			},
+		})
+		if err != nil {
+			log.Fatalf("failed to trial calculate | err: %v", err)
+		}
+
+		fmt.Printf("Trial Calculate Result (reverse: %t):\n", reverse)
+		for i, r := range results {
+			if !r.Status {
+				fmt.Printf("  [%d] %s failed: %s\n", i+1, r.Name, r.ErrMsg)
+				continue
+			}
+			fmt.Printf("  [%d] %s Amount: %d, Order Amount: %d, Income Tax: %d (%.0f%%), VAT: %d, VAT Surcharges: %d, Net: %d\n",
+				i+1, r.Name, r.Amt, r.OrderAmt, r.UserFee, r.UserFeeRatio, r.VaTax, r.VaAddTax, r.UserDueAmt)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package examples
+
+import (
+	"testing"
+
+	"github.com/vogo/vservicesharesdk/payments"
+	"github.com/vogo/vservicesharesdk/wechat"
+)
+
+func TestWechatTransferParams(t *testing.T) {
+	// Payment result of a WeChat payment in the small-change new mode
+	result := &payments.PaymentExecuteResult{
+		PaymentBaseResult: payments.PaymentBaseResult{
+			MerOrderId:  "ORDER_001",
+			State:       payments.PaymentStatePendingConfirm,
+			PackageInfo: "affffddafdfafddffda==",
+			MchId:       "1230000000",
+		},
+	}
+
+	params, err := wechat.NewTransferParams(result, "wx8888888888888888")
+	if err != nil {
+		t.Fatalf("failed to create transfer params: %v", err)
+	}
+
+	// Android/iOS: WXOpenBusinessView.Req with businessType=requestMerchantTransfer
+	query := params.AppQuery()
+	if want := "mchId=1230000000&appId=wx8888888888888888&package=affffddafdfafddffda%3D%3D"; query != want {
+		t.Fatalf("unexpected app query: %s, want: %s", query, want)
+	}
+	t.Logf("businessType: %s, query: %s", wechat.BusinessTypeRequestMerchantTransfer, query)
+
+	// Mini-program / JSAPI: wx.requestMerchantTransfer
+	jsapi, err := params.JSAPIParams()
+	if err != nil {
+		t.Fatalf("failed to create JSAPI params: %v", err)
+	}
+	if want := `{"mchId":"1230000000","appId":"wx8888888888888888","package":"affffddafdfafddffda=="}`; string(jsapi) != want {
+		t.Fatalf("unexpected JSAPI params: %s, want: %s", jsapi, want)
+	}
+	t.Logf("JSAPI params: %s", jsapi)
+}
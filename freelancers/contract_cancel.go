@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package freelancers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// CancelContractState represents the contract termination state.
+type CancelContractState string
+
+const (
+	CancelContractStateSuccess CancelContractState = "1" // the contract is terminated
+	CancelContractStateFailed  CancelContractState = "2" // the termination failed, see RetMsg
+)
+
+// CancelContractRequest represents the request for terminating a freelancer's contract.
+type CancelContractRequest struct {
+	UserName   string `json:"userName"`             // the freelancer's full name
+	IdcardNo   string `json:"idcardNo"`             // the ID card number
+	ProviderId int64  `json:"providerId,omitempty"` // the service provider ID, omit to terminate with all providers
+}
+
+// CancelContractResult represents the result of contract termination.
+type CancelContractResult struct {
+	State  CancelContractState `json:"state"`            // the termination state
+	RetMsg string              `json:"retMsg,omitempty"` // the result description
+}
+
+// CancelContract terminates a freelancer's contract with the service provider,
+// or with all providers of the merchant when ProviderId is omitted.
+//
+// Once terminated, SignContractQuery reports SignStateCancelled.
+func (s *Service) CancelContract(req *CancelContractRequest) (*CancelContractResult, error) {
+	return s.CancelContractContext(context.Background(), req)
+}
+
+// CancelContractContext is like CancelContract but carries the context for cancellation and deadlines.
+func (s *Service) CancelContractContext(ctx context.Context, req *CancelContractRequest) (*CancelContractResult, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.UserName == "" {
+		return nil, fmt.Errorf("userName is required")
+	}
+	if req.IdcardNo == "" {
+		return nil, fmt.Errorf("idcardNo is required")
+	}
+
+	// Call API with function code 6036
+	respData, err := s.client.DoContext(ctx, cores.FunCodeCancelContract, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp CancelContractResult
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package freelancers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// ContractListRequest represents the request for listing contracts.
+type ContractListRequest struct {
+	ProviderId      int64     // the service provider ID
+	CreateTimeBegin time.Time // the start of the contract creation time
+	CreateTimeEnd   time.Time // the end of the contract creation time
+	State           SignState // the sign status: unsigned, signed, pending, failed or cancelled
+	FinishTimeBegin time.Time // the start of the signing finish time, optional
+	FinishTimeEnd   time.Time // the end of the signing finish time, optional
+}
+
+// contractListRequest represents the request data of a contract list page.
+type contractListRequest struct {
+	ProviderId      int64     `json:"providerId"`                // the service provider ID
+	CreateTimeBegin string    `json:"createTimeBegin"`           // the start of the creation time (format: yyyy-MM-dd HH:mm:ss)
+	CreateTimeEnd   string    `json:"createTimeEnd"`             // the end of the creation time (format: yyyy-MM-dd HH:mm:ss)
+	State           SignState `json:"state"`                     // the sign status
+	FinishTimeBegin string    `json:"finishTimeBegin,omitempty"` // the start of the finish time (format: yyyy-MM-dd HH:mm:ss)
+	FinishTimeEnd   string    `json:"finishTimeEnd,omitempty"`   // the end of the finish time (format: yyyy-MM-dd HH:mm:ss)
+	OffsetId        string    `json:"offsetId,omitempty"`        // the offset ID of the last contract of the previous page
+}
+
+// ListContracts lists the contracts of the service provider, newest first.
+//
+// The iterator follows offsetId page by page until the pages run out. It yields
+// a non-nil error and stops when the request is invalid, a page fails, or the
+// context is done.
+func (s *Service) ListContracts(ctx context.Context, req *ContractListRequest) iter.Seq2[SignContractResult, error] {
+	return func(yield func(SignContractResult, error) bool) {
+		// Validate request
+		page, err := newContractListRequest(req)
+		if err != nil {
+			yield(SignContractResult{}, err)
+			return
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(SignContractResult{}, err)
+				return
+			}
+
+			contracts, err := s.listContractPage(ctx, page)
+			if err != nil {
+				yield(SignContractResult{}, err)
+				return
+			}
+
+			for _, contract := range contracts {
+				if !yield(contract, nil) {
+					return
+				}
+			}
+
+			// Stop when the pages run out or the offset does not advance
+			if len(contracts) == 0 {
+				return
+			}
+			offsetId := contracts[len(contracts)-1].OffsetId
+			if offsetId == "" || offsetId == page.OffsetId {
+				return
+			}
+			page.OffsetId = offsetId
+		}
+	}
+}
+
+// newContractListRequest validates the request and builds the first page request.
+func newContractListRequest(req *ContractListRequest) (*contractListRequest, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.ProviderId == 0 {
+		return nil, fmt.Errorf("providerId is required")
+	}
+	if req.CreateTimeBegin.IsZero() {
+		return nil, fmt.Errorf("createTimeBegin is required")
+	}
+	if req.CreateTimeEnd.IsZero() {
+		return nil, fmt.Errorf("createTimeEnd is required")
+	}
+	if req.CreateTimeEnd.Before(req.CreateTimeBegin) {
+		return nil, fmt.Errorf("createTimeEnd must not be before createTimeBegin")
+	}
+	if req.State == SignStateNotFound || req.State < SignStateUnsigned || req.State > SignStateCancelled {
+		return nil, fmt.Errorf("state must be one of 0, 1, 3, 4, 5")
+	}
+
+	return &contractListRequest{
+		ProviderId:      req.ProviderId,
+		CreateTimeBegin: formatDateTime(req.CreateTimeBegin),
+		CreateTimeEnd:   formatDateTime(req.CreateTimeEnd),
+		State:           req.State,
+		FinishTimeBegin: formatDateTime(req.FinishTimeBegin),
+		FinishTimeEnd:   formatDateTime(req.FinishTimeEnd),
+	}, nil
+}
+
+// listContractPage queries a page of contracts.
+func (s *Service) listContractPage(ctx context.Context, req *contractListRequest) ([]SignContractResult, error) {
+	// Call API with function code 6044
+	respData, err := s.client.DoContext(ctx, cores.FunCodeContractList, req)
+	if err != nil {
+		// No more contracts
+		if errors.Is(err, cores.ErrApiRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, nil
+	}
+
+	// Unmarshal decrypted response
+	var resp []SignContractResult
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// formatDateTime formats the time as yyyy-MM-dd HH:mm:ss in China Standard Time, empty if zero.
+func formatDateTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(cores.Location).Format(time.DateTime)
+}
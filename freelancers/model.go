@@ -19,12 +19,13 @@ package freelancers
 
 // SignContractResult represents the result of sign.
 type SignContractResult struct {
-	Name       string    `json:"name"`             // the freelancer's name
-	CardNo     string    `json:"cardNo"`           // the bank card number or payment account
-	IdCard     string    `json:"idCard"`           // the ID card number
-	Mobile     string    `json:"mobile"`           // the phone number registered with bank
-	State      SignState `json:"state"`            // the sign status
-	OtherParam string    `json:"otherParam"`       // other parameters
-	ProviderId int64     `json:"providerId"`       // the service provider ID
-	RetMsg     string    `json:"retMsg,omitempty"` // the failure reason if applicable
+	Name       string    `json:"name"`               // the freelancer's name
+	CardNo     string    `json:"cardNo"`             // the bank card number or payment account
+	IdCard     string    `json:"idCard"`             // the ID card number
+	Mobile     string    `json:"mobile"`             // the phone number registered with bank
+	State      SignState `json:"state"`              // the sign status
+	OtherParam string    `json:"otherParam"`         // other parameters
+	ProviderId int64     `json:"providerId"`         // the service provider ID
+	RetMsg     string    `json:"retMsg,omitempty"`   // the failure reason if applicable
+	OffsetId   string    `json:"offsetId,omitempty"` // the offset ID for paging, returned by ListContracts
 }
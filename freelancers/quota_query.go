@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package freelancers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// QuotaQueryRequest represents the request for querying the remaining payout quota of a freelancer.
+type QuotaQueryRequest struct {
+	ProviderId int64  `json:"providerId"` // the service provider ID
+	Name       string `json:"name"`       // the freelancer's name
+	IdCard     string `json:"idCard"`     // the ID card number
+}
+
+// QuotaResult represents the remaining payout quota of a freelancer.
+type QuotaResult struct {
+	ProviderId int64  `json:"providerId"` // the service provider ID
+	Name       string `json:"name"`       // the freelancer's name
+	IdCard     string `json:"idCard"`     // the ID card number
+	Balance    int64  `json:"balance"`    // the remaining quota in fen
+}
+
+// QueryQuota queries the remaining payout quota of a freelancer under the service provider.
+//
+// Note: Only freelancers who have signed with the service provider can be queried.
+func (s *Service) QueryQuota(providerId int64, name, idCard string) (*QuotaResult, error) {
+	return s.QueryQuotaContext(context.Background(), providerId, name, idCard)
+}
+
+// QueryQuotaContext is like QueryQuota but carries the context for cancellation and deadlines.
+func (s *Service) QueryQuotaContext(ctx context.Context, providerId int64, name, idCard string) (*QuotaResult, error) {
+	// Validate request
+	if providerId == 0 {
+		return nil, fmt.Errorf("providerId is required")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if idCard == "" {
+		return nil, fmt.Errorf("idCard is required")
+	}
+
+	req := &QuotaQueryRequest{
+		ProviderId: providerId,
+		Name:       name,
+		IdCard:     idCard,
+	}
+
+	// Call API with function code 6005
+	respData, err := s.client.DoContext(ctx, cores.FunCodeQuotaQuery, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp QuotaResult
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
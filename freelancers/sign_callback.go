@@ -18,10 +18,10 @@
 package freelancers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-
-	"github.com/vogo/vogo/vlog"
+	"log/slog"
 )
 
 // ParseSignContractCallback parses and validates the contract signing callback request.
@@ -33,7 +33,10 @@ func (s *Service) ParseSignContractCallback(body []byte) (*SignContractResult, e
 		return nil, err
 	}
 
-	vlog.Infof("service share contract sign callback | data: %s", decryptedData)
+	s.client.Logger().LogAttrs(context.Background(), slog.LevelDebug, "service share contract sign callback",
+		slog.String("merchantId", s.client.MerchantID()),
+		slog.Any("data", s.client.RedactValue(decryptedData)),
+	)
 
 	if decryptedData == "" {
 		return nil, fmt.Errorf("empty callback data")
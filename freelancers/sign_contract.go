@@ -18,6 +18,7 @@
 package freelancers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -50,6 +51,11 @@ type SignContractResponse struct {
 //
 // Note: Contracts are validated by merchant ID + name + ID card + phone + provider ID.
 func (s *Service) SignContract(req *SignContractRequest) (*SignContractResponse, error) {
+	return s.SignContractContext(context.Background(), req)
+}
+
+// SignContractContext is like SignContract but carries the context for cancellation and deadlines.
+func (s *Service) SignContractContext(ctx context.Context, req *SignContractRequest) (*SignContractResponse, error) {
 	// Validate request
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
@@ -77,7 +83,7 @@ func (s *Service) SignContract(req *SignContractRequest) (*SignContractResponse,
 	}
 
 	// Call API with function code 6010
-	respData, err := s.client.Do(cores.FunCodeSignContract, req)
+	respData, err := s.client.DoContext(ctx, cores.FunCodeSignContract, req)
 	if err != nil {
 		return nil, err
 	}
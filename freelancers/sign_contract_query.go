@@ -18,6 +18,7 @@
 package freelancers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -33,7 +34,7 @@ const (
 	SignStateNotFound  SignState = 2 // freelancer record not found
 	SignStatePending   SignState = 3 // sign is pending
 	SignStateFailed    SignState = 4 // sign failed
-	SignStateCancelled SignState = 5 // sign cancelled
+	SignStateCancelled SignState = 5 // sign cancelled, e.g. terminated via CancelContract
 )
 
 // SignQueryRequest represents the request for querying freelancer sign status.
@@ -48,6 +49,11 @@ type SignQueryRequest struct {
 //
 // Note: After changing bank cards, no need to re-sign.
 func (s *Service) SignContractQuery(req *SignQueryRequest) (*SignContractResult, error) {
+	return s.SignContractQueryContext(context.Background(), req)
+}
+
+// SignContractQueryContext is like SignContractQuery but carries the context for cancellation and deadlines.
+func (s *Service) SignContractQueryContext(ctx context.Context, req *SignQueryRequest) (*SignContractResult, error) {
 	// Validate request
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
@@ -66,7 +72,7 @@ func (s *Service) SignContractQuery(req *SignQueryRequest) (*SignContractResult,
 	}
 
 	// Call API with function code 6011
-	respData, err := s.client.Do(cores.FunCodeSignContractQuery, req)
+	respData, err := s.client.DoContext(ctx, cores.FunCodeSignContractQuery, req)
 	if err != nil {
 		return nil, err
 	}
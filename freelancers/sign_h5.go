@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package freelancers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+const (
+	// H5SignTokenTTL is the validity period of the token in the H5 signing URL.
+	H5SignTokenTTL = 90 * 24 * time.Hour
+
+	// maxIdCardPicSize is the maximum size of an ID card photo in bytes.
+	maxIdCardPicSize = 1 << 20
+)
+
+// H5SignRequest represents the request for freelancer H5 interactive contract signing.
+type H5SignRequest struct {
+	UserName        string            `json:"userName"`                  // the freelancer's full name
+	CardNo          string            `json:"cardNo"`                    // the bank card number, Alipay account (phone/email), or WeChat OpenID
+	IdCard          string            `json:"idCard"`                    // the ID card number, age typically 18-65
+	Mobile          string            `json:"mobile"`                    // the phone number registered with bank
+	IdCardFrontPic  string            `json:"idCardFrontPic"`            // the ID card front photo in hex format
+	IdCardBackPic   string            `json:"idCardBackPic"`             // the ID card back photo in hex format
+	PaymentType     cores.PaymentType `json:"paymentType"`               // the payment method
+	NotifyUrl       string            `json:"notifyUrl,omitempty"`       // the callback URL for signing results
+	RedirectUrl     string            `json:"redirectUrl,omitempty"`     // the URL of the back button on the signing result page
+	RedirectBtnName string            `json:"redirectBtnName,omitempty"` // the name of the back button on the signing result page
+}
+
+// SetIdCardPics sets the ID card front and back photos, encoding the image bytes in hex.
+func (r *H5SignRequest) SetIdCardPics(front, back []byte) {
+	r.IdCardFrontPic = hex.EncodeToString(front)
+	r.IdCardBackPic = hex.EncodeToString(back)
+}
+
+// H5SignResult represents the result of H5 interactive contract signing.
+type H5SignResult struct {
+	URL       string    // the embeddable signing URL, the token in it must be kept
+	Token     string    // the token in the signing URL
+	ExpiresAt time.Time // the expiry time of the token
+}
+
+// CreateH5SignURL creates an embeddable H5 signing URL for a freelancer.
+//
+// Each call returns a URL with a different token, valid for 90 days. The expiry is read
+// from the token when it carries one, otherwise it is 90 days from now.
+//
+// Note: The signing that follows is validated the same way as SignContract.
+func (s *Service) CreateH5SignURL(req *H5SignRequest) (*H5SignResult, error) {
+	return s.CreateH5SignURLContext(context.Background(), req)
+}
+
+// CreateH5SignURLContext is like CreateH5SignURL but carries the context for cancellation and deadlines.
+func (s *Service) CreateH5SignURLContext(ctx context.Context, req *H5SignRequest) (*H5SignResult, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.UserName == "" {
+		return nil, fmt.Errorf("userName is required")
+	}
+	if req.CardNo == "" {
+		return nil, fmt.Errorf("cardNo is required")
+	}
+	if req.IdCard == "" {
+		return nil, fmt.Errorf("idCard is required")
+	}
+	if req.Mobile == "" {
+		return nil, fmt.Errorf("mobile is required")
+	}
+	if err := validateIdCardPic("idCardFrontPic", req.IdCardFrontPic); err != nil {
+		return nil, err
+	}
+	if err := validateIdCardPic("idCardBackPic", req.IdCardBackPic); err != nil {
+		return nil, err
+	}
+
+	// Call API with function code 6026
+	respData, err := s.client.DoContext(ctx, cores.FunCodeSignH5, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// The resData is the signing URL, either raw or as a JSON string
+	signURL := respData
+	if strings.HasPrefix(respData, `"`) {
+		if err := json.Unmarshal([]byte(respData), &signURL); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return newH5SignResult(signURL, time.Now())
+}
+
+// validateIdCardPic validates an ID card photo in hex format.
+func validateIdCardPic(name, pic string) error {
+	if pic == "" {
+		return fmt.Errorf("%s is required", name)
+	}
+	if len(pic) > 2*maxIdCardPicSize {
+		return fmt.Errorf("%s must be smaller than 1M", name)
+	}
+	if _, err := hex.DecodeString(pic); err != nil {
+		return fmt.Errorf("%s must be hex encoded: %w", name, err)
+	}
+	return nil
+}
+
+// newH5SignResult extracts the token and its expiry from the signing URL.
+func newH5SignResult(signURL string, now time.Time) (*H5SignResult, error) {
+	u, err := url.Parse(signURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	token := u.Query().Get("token")
+	if token == "" {
+		// Single page apps carry the query in the fragment, e.g. /#/sign?token=xxx
+		if _, query, ok := strings.Cut(u.Fragment, "?"); ok {
+			values, _ := url.ParseQuery(query)
+			token = values.Get("token")
+		}
+	}
+
+	expiresAt := now.Add(H5SignTokenTTL)
+	if exp, ok := tokenExpiry(token); ok {
+		expiresAt = exp
+	}
+
+	return &H5SignResult{
+		URL:       signURL,
+		Token:     token,
+		ExpiresAt: expiresAt.In(cores.Location),
+	}, nil
+}
+
+// tokenExpiry reads the exp claim of a JWT token.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(claims.Exp, 0), true
+}
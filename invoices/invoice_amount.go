@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package invoices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// InvoiceAmountQueryRequest represents the request for querying the invoiceable amount.
+type InvoiceAmountQueryRequest struct {
+	ProviderId int64 `json:"providerId"` // the service provider ID
+}
+
+// InvoiceAmountQueryResponse represents the response for invoiceable amount query.
+type InvoiceAmountQueryResponse struct {
+	ProviderId   int64 `json:"providerId"`   // the service provider ID
+	AvailableAmt int64 `json:"availableAmt"` // the invoiceable amount in fen
+}
+
+// QueryInvoiceAmount queries the amount the merchant can still invoice with the given service provider.
+func (s *Service) QueryInvoiceAmount(req *InvoiceAmountQueryRequest) (*InvoiceAmountQueryResponse, error) {
+	return s.QueryInvoiceAmountContext(context.Background(), req)
+}
+
+// QueryInvoiceAmountContext is like QueryInvoiceAmount but carries the context for cancellation and deadlines.
+func (s *Service) QueryInvoiceAmountContext(ctx context.Context, req *InvoiceAmountQueryRequest) (*InvoiceAmountQueryResponse, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.ProviderId == 0 {
+		return nil, fmt.Errorf("providerId is required")
+	}
+
+	// Call API with function code 6012
+	respData, err := s.client.DoContext(ctx, cores.FunCodeInvoiceAmount, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp InvoiceAmountQueryResponse
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
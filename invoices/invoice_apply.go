@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package invoices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// InvoiceApplyRequest represents the request for applying an invoice.
+type InvoiceApplyRequest struct {
+	ProviderId    int64       `json:"providerId"`            // the service provider ID
+	InvoiceTypeId int64       `json:"invoiceTypeId"`         // the invoice category ID
+	Amt           int64       `json:"amt"`                   // the invoice amount in fen
+	InvoiceType   InvoiceType `json:"invoiceType"`           // the invoice type
+	InvoiceMemo   string      `json:"invoiceMemo,omitempty"` // the application note
+	Contact       string      `json:"contact,omitempty"`     // the express recipient, defaults to the merchant profile
+	Mobile        string      `json:"mobile,omitempty"`      // the express phone number, defaults to the merchant profile
+	PostAddress   string      `json:"postAddress,omitempty"` // the express address, defaults to the merchant profile
+	TicketType    TicketType  `json:"ticketType"`            // the invoice medium
+}
+
+// InvoiceApplyResponse represents the response for invoice application.
+type InvoiceApplyResponse struct {
+	ProviderId     int64  `json:"providerId"`            // the service provider ID
+	InvoiceApplyNo string `json:"invoiceApplyNo"`        // the invoice application number
+	InvoiceTypeId  int64  `json:"invoiceTypeId"`         // the invoice category ID
+	Amt            int64  `json:"amt"`                   // the invoice amount in fen
+	InvoiceMemo    string `json:"invoiceMemo,omitempty"` // the application note
+	Contact        string `json:"contact"`               // the express recipient
+	Mobile         string `json:"mobile"`                // the express phone number
+	PostAddress    string `json:"postAddress"`           // the express address
+}
+
+// ApplyInvoice applies for an invoice with the given service provider.
+//
+// Use QueryInvoiceCategories to obtain a valid InvoiceTypeId and QueryInvoiceAmount
+// to check the amount that can still be invoiced. The returned InvoiceApplyNo is used
+// to track the application via QueryInvoiceResult.
+func (s *Service) ApplyInvoice(req *InvoiceApplyRequest) (*InvoiceApplyResponse, error) {
+	return s.ApplyInvoiceContext(context.Background(), req)
+}
+
+// ApplyInvoiceContext is like ApplyInvoice but carries the context for cancellation and deadlines.
+func (s *Service) ApplyInvoiceContext(ctx context.Context, req *InvoiceApplyRequest) (*InvoiceApplyResponse, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.ProviderId == 0 {
+		return nil, fmt.Errorf("providerId is required")
+	}
+	if req.InvoiceTypeId == 0 {
+		return nil, fmt.Errorf("invoiceTypeId is required")
+	}
+	if req.Amt <= 0 {
+		return nil, fmt.Errorf("amt must be greater than 0 fen")
+	}
+	if !req.InvoiceType.IsValid() {
+		return nil, fmt.Errorf("invoiceType must be %s or %s", InvoiceTypeSpecial, InvoiceTypePlain)
+	}
+	if !req.TicketType.IsValid() {
+		return nil, fmt.Errorf("ticketType must be %s or %s", TicketTypePaper, TicketTypeElectronic)
+	}
+
+	// Call API with function code 6013
+	respData, err := s.client.DoContext(ctx, cores.FunCodeInvoiceApply, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp InvoiceApplyResponse
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package invoices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// InvoiceCategoryQueryRequest represents the request for querying invoice categories.
+type InvoiceCategoryQueryRequest struct {
+	ProviderId int64 `json:"providerId"` // the service provider ID
+}
+
+// InvoiceCategory represents an invoice category available to the merchant.
+type InvoiceCategory struct {
+	ProviderId    int64  `json:"providerId"`    // the service provider ID
+	MerId         string `json:"merId"`         // the merchant ID
+	InvoiceTypeId int64  `json:"invoiceTypeId"` // the invoice category ID
+	InvoiceName   string `json:"invoiceName"`   // the invoice category name
+}
+
+// QueryInvoiceCategories queries the invoice categories the merchant can apply for
+// with the given service provider.
+func (s *Service) QueryInvoiceCategories(req *InvoiceCategoryQueryRequest) ([]InvoiceCategory, error) {
+	return s.QueryInvoiceCategoriesContext(context.Background(), req)
+}
+
+// QueryInvoiceCategoriesContext is like QueryInvoiceCategories but carries the context for cancellation and deadlines.
+func (s *Service) QueryInvoiceCategoriesContext(ctx context.Context, req *InvoiceCategoryQueryRequest) ([]InvoiceCategory, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.ProviderId == 0 {
+		return nil, fmt.Errorf("providerId is required")
+	}
+
+	// Call API with function code 6015
+	respData, err := s.client.DoContext(ctx, cores.FunCodeInvoiceCategory, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp []InvoiceCategory
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package invoices
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// InvoiceResultQueryRequest represents the request for querying invoice results.
+type InvoiceResultQueryRequest struct {
+	InvoiceApplyNo string `json:"invoiceApplyNo,omitempty"` // the invoice application number
+	ProviderId     int64  `json:"providerId,omitempty"`     // the service provider ID
+	StartDate      string `json:"startDate,omitempty"`      // the application start date (format: yyyy-MM-dd)
+	EndDate        string `json:"endDate,omitempty"`        // the application end date (format: yyyy-MM-dd)
+}
+
+// QueryInvoiceResult queries the state of invoice applications.
+//
+// Either InvoiceApplyNo or ProviderId must be provided. When querying by ProviderId,
+// StartDate and EndDate narrow the result to applications made within the date range.
+func (s *Service) QueryInvoiceResult(req *InvoiceResultQueryRequest) ([]InvoiceResult, error) {
+	return s.QueryInvoiceResultContext(context.Background(), req)
+}
+
+// QueryInvoiceResultContext is like QueryInvoiceResult but carries the context for cancellation and deadlines.
+func (s *Service) QueryInvoiceResultContext(ctx context.Context, req *InvoiceResultQueryRequest) ([]InvoiceResult, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.InvoiceApplyNo == "" && req.ProviderId == 0 {
+		return nil, fmt.Errorf("invoiceApplyNo or providerId is required")
+	}
+	if req.StartDate != "" {
+		if _, err := time.Parse(time.DateOnly, req.StartDate); err != nil {
+			return nil, fmt.Errorf("startDate must be in format yyyy-MM-dd")
+		}
+	}
+	if req.EndDate != "" {
+		if _, err := time.Parse(time.DateOnly, req.EndDate); err != nil {
+			return nil, fmt.Errorf("endDate must be in format yyyy-MM-dd")
+		}
+	}
+
+	// Call API with function code 6014
+	respData, err := s.client.DoContext(ctx, cores.FunCodeInvoiceResult, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Accept both a single result object and a list of results
+	data := bytes.TrimSpace([]byte(respData))
+	if len(data) > 0 && data[0] == '{' {
+		var result InvoiceResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return []InvoiceResult{result}, nil
+	}
+
+	// Unmarshal decrypted response
+	var resp []InvoiceResult
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp, nil
+}
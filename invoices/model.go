@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package invoices
+
+import "strings"
+
+// InvoiceState represents the invoice processing state.
+type InvoiceState int
+
+const (
+	InvoiceStateIssuing     InvoiceState = 0 // indicates the invoice is being issued
+	InvoiceStateIssued      InvoiceState = 1 // indicates the invoice has been issued
+	InvoiceStateRejected    InvoiceState = 2 // indicates the invoice application was rejected
+	InvoiceStateVoided      InvoiceState = 3 // indicates the invoice was voided
+	InvoiceStateShipped     InvoiceState = 4 // indicates the paper invoice has been shipped
+	InvoiceStateRedReversed InvoiceState = 5 // indicates the invoice was red-reversed (红冲)
+)
+
+// InvoiceType represents the invoice type.
+type InvoiceType string
+
+const (
+	InvoiceTypeSpecial InvoiceType = "SPECIAL" // special VAT invoice (专票)
+	InvoiceTypePlain   InvoiceType = "PLAIN"   // plain VAT invoice (普票)
+)
+
+// IsValid reports whether the invoice type is one of the documented values.
+func (t InvoiceType) IsValid() bool {
+	return t == InvoiceTypeSpecial || t == InvoiceTypePlain
+}
+
+// TicketType represents the invoice medium.
+type TicketType string
+
+const (
+	TicketTypePaper      TicketType = "PAPER"      // paper invoice
+	TicketTypeElectronic TicketType = "ELECTRONIC" // electronic invoice
+)
+
+// IsValid reports whether the ticket type is one of the documented values.
+func (t TicketType) IsValid() bool {
+	return t == TicketTypePaper || t == TicketTypeElectronic
+}
+
+// InvoiceResult represents the result of an invoice application.
+type InvoiceResult struct {
+	MerId           string       `json:"merId"`                     // the merchant ID
+	ProviderId      int64        `json:"providerId"`                // the service provider ID
+	InvoiceApplyNo  string       `json:"invoiceApplyNo"`            // the invoice application number
+	CreateTime      string       `json:"createTime"`                // the application time (format: yyyy-MM-dd HH:mm:ss)
+	InvoiceTypeId   int64        `json:"invoiceTypeId"`             // the invoice category ID
+	Amt             string       `json:"amt"`                       // the invoice amount in fen
+	InvoiceMemo     string       `json:"invoiceMemo,omitempty"`     // the application note
+	State           InvoiceState `json:"state"`                     // the invoice state
+	Contact         string       `json:"contact"`                   // the express recipient
+	Mobile          string       `json:"mobile"`                    // the express phone number
+	PostAddress     string       `json:"postAddress"`               // the express address
+	InvoiceNum      string       `json:"invoiceNum,omitempty"`      // the invoice number
+	InvoiceCode     string       `json:"invoiceCode,omitempty"`     // the invoice code
+	ExpressId       string       `json:"expressId,omitempty"`       // the express company
+	TrackNo         string       `json:"trackNo,omitempty"`         // the express tracking number
+	InvoiceFileList string       `json:"invoiceFileList,omitempty"` // the invoice file URLs separated by ","
+}
+
+// InvoiceFiles returns the invoice file URLs as a slice.
+func (r *InvoiceResult) InvoiceFiles() []string {
+	if r.InvoiceFileList == "" {
+		return nil
+	}
+
+	var files []string
+	for _, f := range strings.Split(r.InvoiceFileList, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// UploadBatchResponse represents the response for batch order upload.
+type UploadBatchResponse struct {
+	BatchNo int64  `json:"batchNo"` // the unique batch number generated by the platform
+	ResCode string `json:"resCode"` // the result code
+	ResMsg  string `json:"resMsg"`  // the result message
+}
+
+// UploadBatch uploads a batch of orders to the platform without paying them.
+//
+// The uploaded orders must be approved and paid by the merchant in the merchant portal,
+// after which their status can be queried via QueryUploadedBatch. Use Payment instead
+// when the orders should be paid immediately.
+//
+// Note: Uploaded orders do not support notifyUrl nor WeChat payment, and req.QuotaCheck must be off.
+func (s *Service) UploadBatch(req *PaymentRequest) (*UploadBatchResponse, error) {
+	return s.UploadBatchContext(context.Background(), req)
+}
+
+// UploadBatchContext is like UploadBatch but carries the context for cancellation and deadlines.
+func (s *Service) UploadBatchContext(ctx context.Context, req *PaymentRequest) (*UploadBatchResponse, error) {
+	// Validate request
+	if err := validatePaymentRequest(req); err != nil {
+		return nil, err
+	}
+	if req.QuotaCheck != QuotaCheckOff {
+		return nil, fmt.Errorf("quotaCheck is not supported for batch upload")
+	}
+	for i, item := range req.PayItems {
+		if item.NotifyUrl != "" {
+			return nil, fmt.Errorf("payItems[%d].notifyUrl is not supported for batch upload", i)
+		}
+		if item.PaymentType == cores.PaymentTypeWeChat {
+			return nil, fmt.Errorf("payItems[%d].paymentType WeChat is not supported for batch upload", i)
+		}
+	}
+
+	// Call API with function code 6022
+	respData, err := s.client.DoContext(ctx, cores.FunCodeBatchUpload, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp UploadBatchResponse
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
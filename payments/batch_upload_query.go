@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// UploadedBatchQueryItem represents a query filter for specific uploaded orders.
+type UploadedBatchQueryItem struct {
+	MerOrderId string `json:"merOrderId,omitempty"` // merchant order ID
+}
+
+// UploadedBatchQueryRequest represents the request for querying an uploaded batch.
+type UploadedBatchQueryRequest struct {
+	MerId      string                   `json:"merId"`                // merchant ID (defaults to the client merchant ID)
+	MerBatchId string                   `json:"merBatchId"`           // merchant batch number
+	QueryItems []UploadedBatchQueryItem `json:"queryItems,omitempty"` // query items
+}
+
+// QueryUploadedBatch retrieves the status of orders uploaded via UploadBatch.
+//
+// IMPORTANT NOTES:
+// - Omitting QueryItems returns all orders in the batch
+// - Orders paid via Payment must be queried via PaymentQuery instead
+// - Only the final states (success, failure) should be used for processing
+func (s *Service) QueryUploadedBatch(req *UploadedBatchQueryRequest) (*BatchOrderBatchResult, error) {
+	return s.QueryUploadedBatchContext(context.Background(), req)
+}
+
+// QueryUploadedBatchContext is like QueryUploadedBatch but carries the context for cancellation and deadlines.
+func (s *Service) QueryUploadedBatchContext(ctx context.Context, req *UploadedBatchQueryRequest) (*BatchOrderBatchResult, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.MerBatchId == "" {
+		return nil, fmt.Errorf("merBatchId is required")
+	}
+
+	// Default to the merchant ID of the client without modifying the caller's request
+	query := *req
+	if query.MerId == "" {
+		query.MerId = s.client.MerchantID()
+	}
+
+	// Call API with function code 6023
+	respData, err := s.client.DoContext(ctx, cores.FunCodeBatchUploadQuery, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp BatchOrderBatchResult
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
@@ -68,3 +68,38 @@ type PaymentBatchResult struct {
 	MerBatchId string          `json:"merBatchId"` // merchant batch number
 	QueryItems []PaymentResult `json:"queryItems"` // query items
 }
+
+// BatchOrderState represents the state of an order uploaded via UploadBatch.
+type BatchOrderState int
+
+const (
+	BatchOrderStatePending    BatchOrderState = 0 // indicates the order is waiting to be paid in the merchant portal
+	BatchOrderStateProcessing BatchOrderState = 1 // indicates the payment is being processed
+	BatchOrderStateSuccess    BatchOrderState = 3 // indicates the payment succeeded
+	BatchOrderStateFailed     BatchOrderState = 4 // indicates the payment failed
+	BatchOrderStateCancelled  BatchOrderState = 7 // indicates the order was cancelled
+)
+
+// BatchOrderResult represents the detailed result of an uploaded batch order.
+type BatchOrderResult struct {
+	MerOrderId   string          `json:"merOrderId"`   // the merchant order ID
+	OrderNo      int64           `json:"orderNo"`      // the platform order number (use as primary transaction identifier)
+	State        BatchOrderState `json:"state"`        // the order state
+	Amt          int64           `json:"amt"`          // the payment amount in fen
+	Fee          int64           `json:"fee"`          // the service fee in fen
+	UserFee      int64           `json:"userFee"`      // the user's fee in fen
+	VaTax        int64           `json:"vaTax"`        // the VAT tax amount in fen
+	VaAddTax     int64           `json:"vaAddTax"`     // the VAT additional tax amount in fen
+	UserDueAmt   int64           `json:"userDueAmt"`   // the amount due to user in fen
+	UserFeeRatio float64         `json:"userFeeRatio"` // the user's fee ratio
+	ResMsg       string          `json:"resMsg"`       // the result message
+	CreateTime   string          `json:"createTime"`   // the order creation time (format: yyyy-MM-dd HH:mm:ss)
+	EndTime      string          `json:"endTime"`      // the transaction completion time (format: yyyy-MM-dd HH:mm:ss)
+}
+
+// BatchOrderBatchResult represents the response for uploaded batch order query.
+type BatchOrderBatchResult struct {
+	MerId      string             `json:"merId"`      // merchant ID
+	MerBatchId string             `json:"merBatchId"` // merchant batch number
+	QueryItems []BatchOrderResult `json:"queryItems"` // query items
+}
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// OneClickPayment processes batch payment transactions via the one-click payout (一键下发) interface.
+//
+// The request and response have the same shape as Payment, but the interface must be enabled
+// for the merchant by the platform. When it is not, cores.ErrApiOneClickPaymentNotEnabled is
+// returned and callers can fall back to Payment:
+//
+//	resp, err := s.OneClickPayment(req)
+//	if errors.Is(err, cores.ErrApiOneClickPaymentNotEnabled) {
+//		resp, err = s.Payment(req)
+//	}
+//
+// IMPORTANT: The synchronous response only indicates that the system has received the request.
+// Always verify the final status via async notifications or OneClickPaymentQuery.
+//
+// req.QuotaCheck is honoured as in Payment.
+func (s *Service) OneClickPayment(req *PaymentRequest) (*PaymentResponse, error) {
+	return s.OneClickPaymentContext(context.Background(), req)
+}
+
+// OneClickPaymentContext is like OneClickPayment but carries the context for cancellation and deadlines.
+func (s *Service) OneClickPaymentContext(ctx context.Context, req *PaymentRequest) (*PaymentResponse, error) {
+	// Validate request
+	if err := validatePaymentRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Check the payees' remaining quota
+	req, dropped, err := s.checkQuota(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call API with function code 6029
+	respData, err := s.client.DoContext(ctx, cores.FunCodeOneClickPayment, req)
+	if errors.Is(err, cores.ErrRetriedDuplicate) {
+		return s.resolveRetriedPayment(ctx, req, dropped, err, s.OneClickPaymentQueryContext)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp PaymentResponse
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	resp.DroppedItems = dropped
+
+	return &resp, nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// OneClickPaymentQuery retrieves payment status for batches paid via OneClickPayment.
+//
+// IMPORTANT NOTES:
+// - Omitting QueryItems returns all orders in the batch
+// - Error codes 6000 or 6042 indicate communication issues only, NOT transaction failures
+// - Batches paid via Payment must be queried via PaymentQuery instead
+func (s *Service) OneClickPaymentQuery(req *PaymentQueryRequest) (*PaymentBatchResult, error) {
+	return s.OneClickPaymentQueryContext(context.Background(), req)
+}
+
+// OneClickPaymentQueryContext is like OneClickPaymentQuery but carries the context for cancellation and deadlines.
+func (s *Service) OneClickPaymentQueryContext(ctx context.Context, req *PaymentQueryRequest) (*PaymentBatchResult, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.MerBatchId == "" {
+		return nil, fmt.Errorf("merBatchId is required")
+	}
+
+	// Call API with function code 6030
+	respData, err := s.client.DoContext(ctx, cores.FunCodeOneClickQuery, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp PaymentBatchResult
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
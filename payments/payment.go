@@ -18,8 +18,11 @@
 package payments
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/vogo/vservicesharesdk/cores"
 )
@@ -43,14 +46,24 @@ type PaymentRequest struct {
 	PayItems   []PaymentItem `json:"payItems"`   // the list of payment items
 	TaskId     int64         `json:"taskId"`     // the task code for payment reason
 	ProviderId int64         `json:"providerId"` // the service provider ID
+
+	QuotaCheck QuotaCheckMode `json:"-"` // how Payment and OneClickPayment check the payees' remaining quota before submitting, not sent
 }
 
 // PaymentResponse represents the response for batch payment.
+//
+// When a retried payout is rejected as a duplicate, the response is synthesized from the
+// payment query (6002) of the batch the earlier attempt submitted, and Synthesized is set.
+// Then failed and cancelled orders count as rejected and all others as accepted, and
+// PayResultList carries the queried states and result codes, which may be empty.
 type PaymentResponse struct {
 	SuccessNum    int                    `json:"successNum"`    // the count of accepted orders
 	FailureNum    int                    `json:"failureNum"`    // the count of rejected orders
 	MerBatchId    string                 `json:"merBatchId"`    // the merchant batch number
 	PayResultList []PaymentExecuteResult `json:"payResultList"` // the list of payment results
+
+	DroppedItems []QuotaExceededItem `json:"-"` // the items dropped by the quota check, not submitted
+	Synthesized  bool                `json:"-"` // whether the response was synthesized from the payment query
 }
 
 // Payment processes batch payment transactions for multiple freelancers.
@@ -60,59 +73,135 @@ type PaymentResponse struct {
 // async notifications or the query interface.
 //
 // Single transaction limits: ¥0.1 to ¥98,000 (10 to 9,800,000 fen).
+//
+// When retries of 6001 are enabled via RetryPolicy.RetryWrites and a retry is rejected as a
+// duplicate, the batch submitted by the earlier attempt is queried and returned instead,
+// provided it holds the same orders; see PaymentResponse.
+//
+// When req.QuotaCheck is set, the payees' remaining quota (6005) is checked before
+// submitting; see QuotaCheckMode.
 func (s *Service) Payment(req *PaymentRequest) (*PaymentResponse, error) {
+	return s.PaymentContext(context.Background(), req)
+}
+
+// PaymentContext is like Payment but carries the context for cancellation and deadlines.
+func (s *Service) PaymentContext(ctx context.Context, req *PaymentRequest) (*PaymentResponse, error) {
 	// Validate request
+	if err := validatePaymentRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Check the payees' remaining quota
+	req, dropped, err := s.checkQuota(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call API with function code 6001
+	respData, err := s.client.DoContext(ctx, cores.FunCodePayment, req)
+	if errors.Is(err, cores.ErrRetriedDuplicate) {
+		return s.resolveRetriedPayment(ctx, req, dropped, err, s.PaymentQueryContext)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp PaymentResponse
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	resp.DroppedItems = dropped
+
+	return &resp, nil
+}
+
+// resolveRetriedPayment queries the batch of a retried payout rejected as a duplicate, which an
+// earlier attempt has submitted, and returns its orders as the payment response.
+// It returns err if the batch does not hold exactly the orders of req, as then the batch number
+// was reused by another batch rather than submitted by the earlier attempt.
+func (s *Service) resolveRetriedPayment(ctx context.Context, req *PaymentRequest, dropped []QuotaExceededItem, err error,
+	query func(ctx context.Context, req *PaymentQueryRequest) (*PaymentBatchResult, error)) (*PaymentResponse, error) {
+	batch, queryErr := query(ctx, &PaymentQueryRequest{MerBatchId: req.MerBatchId})
+	if queryErr != nil {
+		return nil, errors.Join(err, fmt.Errorf("failed to query batch %s: %w", req.MerBatchId, queryErr))
+	}
+
+	// The queried batch must hold the orders of the request
+	if len(batch.QueryItems) != len(req.PayItems) {
+		return nil, err
+	}
+	orders := make(map[string]bool, len(req.PayItems))
+	for _, item := range req.PayItems {
+		orders[item.MerOrderId] = true
+	}
+	for _, item := range batch.QueryItems {
+		if !orders[item.MerOrderId] {
+			return nil, err
+		}
+		delete(orders, item.MerOrderId)
+	}
+
+	resp := &PaymentResponse{
+		MerBatchId:   req.MerBatchId,
+		DroppedItems: dropped,
+		Synthesized:  true,
+	}
+	for _, item := range batch.QueryItems {
+		if item.State == PaymentStateFailed || item.State == PaymentStateCancelled {
+			resp.FailureNum++
+		} else {
+			resp.SuccessNum++
+		}
+		resp.PayResultList = append(resp.PayResultList, PaymentExecuteResult{
+			PaymentBaseResult: item.PaymentBaseResult,
+			OrderNo:           strconv.FormatInt(item.OrderNo, 10),
+		})
+	}
+	return resp, nil
+}
+
+// validatePaymentRequest validates the batch and its payment items.
+func validatePaymentRequest(req *PaymentRequest) error {
 	if req == nil {
-		return nil, fmt.Errorf("request cannot be nil")
+		return fmt.Errorf("request cannot be nil")
 	}
 	if req.MerBatchId == "" {
-		return nil, fmt.Errorf("merBatchId is required")
+		return fmt.Errorf("merBatchId is required")
 	}
 	if len(req.PayItems) == 0 {
-		return nil, fmt.Errorf("payItems cannot be empty")
+		return fmt.Errorf("payItems cannot be empty")
 	}
 	if req.ProviderId == 0 {
-		return nil, fmt.Errorf("providerId is required")
+		return fmt.Errorf("providerId is required")
 	}
 
 	// Validate each payment item
 	for i, item := range req.PayItems {
 		if item.MerOrderId == "" {
-			return nil, fmt.Errorf("payItems[%d].merOrderId is required", i)
+			return fmt.Errorf("payItems[%d].merOrderId is required", i)
 		}
 		if item.Amt < 10 || item.Amt > 9800000 {
-			return nil, fmt.Errorf("payItems[%d].amt must be between 10 and 9800000 fen", i)
+			return fmt.Errorf("payItems[%d].amt must be between 10 and 9800000 fen", i)
 		}
 		if item.PayeeName == "" {
-			return nil, fmt.Errorf("payItems[%d].payeeName is required", i)
+			return fmt.Errorf("payItems[%d].payeeName is required", i)
 		}
 		if item.PayeeAcc == "" {
-			return nil, fmt.Errorf("payItems[%d].payeeAcc is required", i)
+			return fmt.Errorf("payItems[%d].payeeAcc is required", i)
 		}
 		if item.IdCard == "" {
-			return nil, fmt.Errorf("payItems[%d].idCard is required", i)
+			return fmt.Errorf("payItems[%d].idCard is required", i)
 		}
 		if item.Mobile == "" {
-			return nil, fmt.Errorf("payItems[%d].mobile is required", i)
+			return fmt.Errorf("payItems[%d].mobile is required", i)
 		}
 	}
 
-	// Call API with function code 6001
-	respData, err := s.client.Do(cores.FunCodePayment, req)
-	if err != nil {
-		return nil, err
-	}
-
-	// Handle empty response
-	if respData == "" {
-		return nil, fmt.Errorf("empty response data")
-	}
-
-	// Unmarshal decrypted response
-	var resp PaymentResponse
-	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	return &resp, nil
+	return nil
 }
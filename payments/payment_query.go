@@ -18,6 +18,7 @@
 package payments
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -43,6 +44,11 @@ type PaymentQueryRequest struct {
 // - Error codes 6000 or 6042 indicate communication issues only, NOT transaction failures
 // - Always use OrderNo as the primary transaction identifier to prevent duplicate processing
 func (s *Service) PaymentQuery(req *PaymentQueryRequest) (*PaymentBatchResult, error) {
+	return s.PaymentQueryContext(context.Background(), req)
+}
+
+// PaymentQueryContext is like PaymentQuery but carries the context for cancellation and deadlines.
+func (s *Service) PaymentQueryContext(ctx context.Context, req *PaymentQueryRequest) (*PaymentBatchResult, error) {
 	// Validate request
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
@@ -52,7 +58,7 @@ func (s *Service) PaymentQuery(req *PaymentQueryRequest) (*PaymentBatchResult, e
 	}
 
 	// Call API with function code 6002
-	respData, err := s.client.Do(cores.FunCodePaymentQuery, req)
+	respData, err := s.client.DoContext(ctx, cores.FunCodePaymentQuery, req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,141 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/freelancers"
+)
+
+// QuotaCheckMode represents how Payment and OneClickPayment check the payees' remaining quota before submitting.
+//
+// Amounts are aggregated per payee in item order, so an item exceeds the quota when
+// it does not fit in what the payee's earlier items in the batch leave.
+//
+// A payee whose quota lookup is rejected by the platform, e.g. an unsigned payee, fails the
+// whole batch in Flag mode and has its items dropped in Drop mode, with QuotaExceededItem.Err set.
+// Communication errors (6000, 6042, 6102 and transport failures) fail the batch in both modes.
+type QuotaCheckMode int
+
+const (
+	QuotaCheckOff  QuotaCheckMode = 0 // submits the batch without checking quotas
+	QuotaCheckFlag QuotaCheckMode = 1 // rejects the whole batch with a *QuotaExceededError
+	QuotaCheckDrop QuotaCheckMode = 2 // drops the exceeding items and submits the rest
+)
+
+// QuotaExceededItem represents a payment item exceeding the payee's remaining quota.
+type QuotaExceededItem struct {
+	Index      int    // the index of the item in PayItems
+	MerOrderId string // the merchant order ID
+	PayeeName  string // the payee's name
+	IdCard     string // the payee's ID card number
+	Amt        int64  // the payment amount in fen
+	Balance    int64  // the payee's remaining quota in fen left for this item
+	Err        error  // the error of the quota lookup if the payee's quota is unknown, nil otherwise
+}
+
+// QuotaExceededError is returned when payment items exceed the payees' remaining quota.
+//
+// It matches cores.ErrApiAmountLimitExceeded with errors.Is.
+type QuotaExceededError struct {
+	Items []QuotaExceededItem // the exceeding items
+}
+
+// Error implements the error interface.
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%d payment items exceed the remaining quota, first: payItems[%d] (merOrderId %s, amt %d, balance %d)",
+		len(e.Items), e.Items[0].Index, e.Items[0].MerOrderId, e.Items[0].Amt, e.Items[0].Balance)
+}
+
+// Is reports whether the target is cores.ErrApiAmountLimitExceeded.
+func (e *QuotaExceededError) Is(target error) bool {
+	return cores.ErrApiAmountLimitExceeded.Is(target)
+}
+
+// checkQuota looks up the quota of the distinct payees in the batch and applies the quota check mode of the request.
+//
+// It returns the request to submit and the dropped items.
+func (s *Service) checkQuota(ctx context.Context, req *PaymentRequest) (*PaymentRequest, []QuotaExceededItem, error) {
+	if req.QuotaCheck == QuotaCheckOff {
+		return req, nil, nil
+	}
+
+	freelancerService := freelancers.NewService(s.client)
+	balances := make(map[string]int64)
+	lookupErrs := make(map[string]error)
+
+	var exceeded []QuotaExceededItem
+	kept := make([]PaymentItem, 0, len(req.PayItems))
+
+	for i, item := range req.PayItems {
+		balance, ok := balances[item.IdCard]
+		if !ok {
+			quota, err := freelancerService.QueryQuotaContext(ctx, req.ProviderId, item.PayeeName, item.IdCard)
+			if err != nil && (req.QuotaCheck != QuotaCheckDrop || !isPayeeRejected(err)) {
+				return nil, nil, fmt.Errorf("failed to query quota of payItems[%d]: %w", i, err)
+			}
+			if err != nil {
+				lookupErrs[item.IdCard] = err
+			} else {
+				balance = quota.Balance
+			}
+		}
+
+		if lookupErr := lookupErrs[item.IdCard]; lookupErr != nil || item.Amt > balance {
+			exceeded = append(exceeded, QuotaExceededItem{
+				Index:      i,
+				MerOrderId: item.MerOrderId,
+				PayeeName:  item.PayeeName,
+				IdCard:     item.IdCard,
+				Amt:        item.Amt,
+				Balance:    balance,
+				Err:        lookupErr,
+			})
+		} else {
+			balance -= item.Amt
+			kept = append(kept, item)
+		}
+		balances[item.IdCard] = balance
+	}
+
+	if len(exceeded) == 0 {
+		return req, nil, nil
+	}
+	if req.QuotaCheck == QuotaCheckFlag || len(kept) == 0 {
+		return nil, nil, &QuotaExceededError{Items: exceeded}
+	}
+
+	checked := *req
+	checked.PayItems = kept
+	return &checked, exceeded, nil
+}
+
+// isPayeeRejected reports whether the quota lookup was rejected by the platform for the payee,
+// rather than failed to communicate.
+func isPayeeRejected(err error) bool {
+	var apiErr *cores.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return !errors.Is(err, cores.ErrApiUnknown) && !errors.Is(err, cores.ErrApiRequestTooFrequent) &&
+		!errors.Is(err, cores.ErrApiRequestTimeout)
+}
@@ -0,0 +1,227 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// MaxReceiptSize is the maximum size of a downloaded receipt file in bytes.
+const MaxReceiptSize = 16 << 20
+
+// receiptExtensions are the file extensions receipts are stored with, the first being the default.
+var receiptExtensions = []string{".pdf", ".png", ".jpg", ".jpeg"}
+
+// ReceiptStorage stores archived electronic receipt files.
+type ReceiptStorage interface {
+	// Exists reports whether a receipt file with the given name has been stored.
+	Exists(name string) (bool, error)
+
+	// Save stores the receipt file content read from r under the given name.
+	Save(name string, r io.Reader) error
+}
+
+// LocalReceiptStorage stores receipt files in a local directory.
+type LocalReceiptStorage struct {
+	dir string
+}
+
+// NewLocalReceiptStorage creates a receipt storage in the given local directory.
+func NewLocalReceiptStorage(dir string) *LocalReceiptStorage {
+	return &LocalReceiptStorage{
+		dir: dir,
+	}
+}
+
+// Exists reports whether the receipt file exists in the directory.
+func (s *LocalReceiptStorage) Exists(name string) (bool, error) {
+	_, err := os.Stat(filepath.Join(s.dir, name))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+// Save writes the receipt file into the directory.
+// The content is written to a temporary file first so partial downloads are never kept.
+func (s *LocalReceiptStorage) Save(name string, r io.Reader) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create receipt directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create receipt file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write receipt file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write receipt file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("failed to save receipt file: %w", err)
+	}
+
+	return nil
+}
+
+// ReceiptArchiveResult represents the result of archiving an electronic receipt.
+type ReceiptArchiveResult struct {
+	*ReceiptResult
+	Name     string // the name of the stored receipt file
+	Archived bool   // whether the receipt file is stored, false if the receipt is not yet available
+}
+
+// ReceiptArchiver downloads electronic receipts and stores them before their URLs expire.
+type ReceiptArchiver struct {
+	service *Service
+	storage ReceiptStorage
+}
+
+// NewReceiptArchiver creates a new receipt archiver.
+func NewReceiptArchiver(service *Service, storage ReceiptStorage) *ReceiptArchiver {
+	return &ReceiptArchiver{
+		service: service,
+		storage: storage,
+	}
+}
+
+// Archive queries the electronic receipt of a payment order and stores the receipt file.
+//
+// The file is named after the merchant order ID. Receipts already stored are neither queried
+// nor downloaded again, and their result only carries the order and the file name.
+// When the receipt is not yet available, a result with Archived false is returned so the
+// caller can try again later. Files larger than MaxReceiptSize are rejected.
+func (a *ReceiptArchiver) Archive(merBatchId, merOrderId string) (*ReceiptArchiveResult, error) {
+	return a.ArchiveContext(context.Background(), merBatchId, merOrderId)
+}
+
+// ArchiveContext is like Archive but carries the context for cancellation and deadlines.
+func (a *ReceiptArchiver) ArchiveContext(ctx context.Context, merBatchId, merOrderId string) (*ReceiptArchiveResult, error) {
+	// Skip the query of receipts already stored
+	for _, ext := range receiptExtensions {
+		name := receiptBaseName(merOrderId) + ext
+		exists, err := a.storage.Exists(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check archived receipt: %w", err)
+		}
+		if exists {
+			return &ReceiptArchiveResult{
+				ReceiptResult: &ReceiptResult{MerBatchId: merBatchId, MerOrderId: merOrderId, Available: true},
+				Name:          name,
+				Archived:      true,
+			}, nil
+		}
+	}
+
+	receipt, err := a.service.QueryReceiptContext(ctx, merBatchId, merOrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReceiptArchiveResult{ReceiptResult: receipt}
+	if !receipt.Available {
+		return result, nil
+	}
+
+	result.Name = receiptFileName(merOrderId, receipt.ReceiptUrl)
+
+	// Download receipt file
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, receipt.ReceiptUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download receipt: %w", err)
+	}
+	resp, err := a.service.client.HTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download receipt: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download receipt: HTTP %d", resp.StatusCode)
+	}
+	if resp.ContentLength > MaxReceiptSize {
+		return nil, fmt.Errorf("failed to download receipt: larger than %d bytes", MaxReceiptSize)
+	}
+
+	// Fail the save rather than store a truncated file
+	body := &receiptSizeLimiter{r: io.LimitReader(resp.Body, MaxReceiptSize+1)}
+	if err := a.storage.Save(result.Name, body); err != nil {
+		return nil, err
+	}
+	result.Archived = true
+
+	return result, nil
+}
+
+// receiptSizeLimiter fails reads beyond MaxReceiptSize bytes.
+type receiptSizeLimiter struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader.
+func (l *receiptSizeLimiter) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.n > MaxReceiptSize {
+		return n, fmt.Errorf("failed to download receipt: larger than %d bytes", MaxReceiptSize)
+	}
+	return n, err
+}
+
+// receiptFileName builds the storage name of a receipt from the merchant order ID and
+// the file extension of the receipt URL, one of receiptExtensions and ".pdf" by default.
+func receiptFileName(merOrderId, receiptUrl string) string {
+	ext := receiptExtensions[0]
+	if u, err := url.Parse(receiptUrl); err == nil {
+		if e := strings.ToLower(path.Ext(u.Path)); slices.Contains(receiptExtensions, e) {
+			ext = e
+		}
+	}
+	return receiptBaseName(merOrderId) + ext
+}
+
+// receiptBaseName returns the merchant order ID kept flat so it cannot escape the storage directory.
+func receiptBaseName(merOrderId string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '_'
+		}
+		return r
+	}, merOrderId)
+}
@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// ReceiptQueryRequest represents the request for querying an electronic receipt.
+type ReceiptQueryRequest struct {
+	MerBatchId string `json:"merBatchId,omitempty"` // merchant batch number
+	MerOrderId string `json:"merOrderId"`           // merchant order ID
+}
+
+// ReceiptResult represents the electronic receipt of a payment order.
+type ReceiptResult struct {
+	MerId      string `json:"merId"`      // merchant ID
+	MerBatchId string `json:"merBatchId"` // merchant batch number
+	MerOrderId string `json:"merOrderId"` // merchant order ID
+	ReceiptUrl string `json:"receiptUrl"` // the receipt download URL, valid for 30 days
+	Available  bool   `json:"-"`          // whether the receipt is available
+}
+
+// QueryReceipt queries the electronic receipt of a payment order.
+//
+// IMPORTANT NOTES:
+// - Receipts are available from T+2 and only for bank card payments
+// - When no receipt is available yet, a result with Available false is returned instead of an error
+// - The receipt URL expires after 30 days, use ReceiptArchiver to keep a copy
+func (s *Service) QueryReceipt(merBatchId, merOrderId string) (*ReceiptResult, error) {
+	return s.QueryReceiptContext(context.Background(), merBatchId, merOrderId)
+}
+
+// QueryReceiptContext is like QueryReceipt but carries the context for cancellation and deadlines.
+func (s *Service) QueryReceiptContext(ctx context.Context, merBatchId, merOrderId string) (*ReceiptResult, error) {
+	// Validate request
+	if merOrderId == "" {
+		return nil, fmt.Errorf("merOrderId is required")
+	}
+
+	req := &ReceiptQueryRequest{
+		MerBatchId: merBatchId,
+		MerOrderId: merOrderId,
+	}
+
+	// Call API with function code 6024
+	respData, err := s.client.DoContext(ctx, cores.FunCodeReceiptQuery, req)
+	if err != nil {
+		if errors.Is(err, cores.ErrApiNoElectronicReceipt) {
+			return &ReceiptResult{MerBatchId: merBatchId, MerOrderId: merOrderId}, nil
+		}
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp ReceiptResult
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	resp.Available = resp.ReceiptUrl != ""
+
+	return &resp, nil
+}
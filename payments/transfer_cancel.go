@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// CancelTransferRequest represents the request for cancelling a pending WeChat transfer.
+// Either MerBatchId with MerOrderId, or OrderNo must be provided.
+type CancelTransferRequest struct {
+	MerBatchId string `json:"merBatchId,omitempty"` // merchant batch number
+	MerOrderId string `json:"merOrderId,omitempty"` // merchant order ID
+	OrderNo    string `json:"orderNo,omitempty"`    // platform order number
+}
+
+// CancelTransferResponse represents the response for transfer cancellation.
+type CancelTransferResponse struct {
+	MerBatchId string `json:"merBatchId,omitempty"` // merchant batch number
+	MerOrderId string `json:"merOrderId"`           // merchant order ID
+	OrderNo    int64  `json:"orderNo"`              // platform order number
+}
+
+// CancelTransfer cancels a WeChat small-change transfer that is still waiting for the user
+// to confirm the receipt (PaymentStatePendingConfirm).
+//
+// IMPORTANT NOTES:
+// - Only orders in state 6 (pending confirmation) can be cancelled, otherwise 6103 is returned
+// - A successful response only means the cancellation was accepted; verify the final state via PaymentQuery
+func (s *Service) CancelTransfer(req *CancelTransferRequest) (*CancelTransferResponse, error) {
+	return s.CancelTransferContext(context.Background(), req)
+}
+
+// CancelTransferContext is like CancelTransfer but carries the context for cancellation and deadlines.
+func (s *Service) CancelTransferContext(ctx context.Context, req *CancelTransferRequest) (*CancelTransferResponse, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	byMerOrder := req.MerBatchId != "" || req.MerOrderId != ""
+	if byMerOrder && req.OrderNo != "" {
+		return nil, fmt.Errorf("either merBatchId with merOrderId or orderNo is required, not both")
+	}
+	if !byMerOrder && req.OrderNo == "" {
+		return nil, fmt.Errorf("either merBatchId with merOrderId or orderNo is required")
+	}
+	if byMerOrder && (req.MerBatchId == "" || req.MerOrderId == "") {
+		return nil, fmt.Errorf("merBatchId and merOrderId must be provided together")
+	}
+
+	// Call API with function code 6043
+	respData, err := s.client.DoContext(ctx, cores.FunCodeTransferCancel, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp CancelTransferResponse
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,175 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// MaxTrialUsers is the maximum number of users in a single trial calculation.
+const MaxTrialUsers = 50
+
+// TrialUser represents a freelancer in a trial calculation.
+type TrialUser struct {
+	MerOrderId string `json:"merOrderId,omitempty"` // the merchant order ID
+	Name       string `json:"name"`                 // the freelancer's name
+	IdCardNo   string `json:"idCardNo"`             // the ID card number
+	Amt        int64  `json:"amt"`                  // the trial amount in fen, gross or net by IfReverse
+}
+
+// TrialCalculateRequest represents the request for continuous-labour tax trial calculation.
+type TrialCalculateRequest struct {
+	ProviderId int64       `json:"providerId"` // the service provider ID
+	IfReverse  bool        `json:"ifReverse"`  // true to calculate gross from net, false to calculate net from gross
+	UserList   []TrialUser `json:"userList"`   // the list of freelancers, at most 50 without duplicates
+}
+
+// TrialResult represents the trial calculation result of a freelancer.
+type TrialResult struct {
+	MerOrderId   string  `json:"merOrderId"`   // the merchant order ID
+	Name         string  `json:"name"`         // the freelancer's name
+	IdCardNo     string  `json:"idCardNo"`     // the ID card number
+	ProviderId   int64   `json:"providerId"`   // the service provider ID
+	Amt          int64   `json:"amt"`          // the trial amount in fen
+	OrderAmt     int64   `json:"orderAmt"`     // the payment amount in fen
+	UserFeeRatio float64 `json:"userFeeRatio"` // the withholding rate of the income tax bracket, 3 for 3%
+	UserFee      int64   `json:"userFee"`      // the income tax payable in fen
+	VaTax        int64   `json:"vaTax"`        // the VAT tax amount in fen
+	VaAddTax     int64   `json:"vaAddTax"`     // the VAT additional tax amount in fen
+	UserDueAmt   int64   `json:"userDueAmt"`   // the net amount to the freelancer in fen
+	Status       bool    `json:"status"`       // whether the trial calculation succeeded
+	ErrMsg       string  `json:"errMsg"`       // the failure reason if applicable
+}
+
+// TrialCalculate calculates the withholding tax, VAT, VAT surcharges and net amount of freelancers.
+//
+// IMPORTANT NOTES:
+// - The calculation is based on the current settlement, nothing is recorded and no income is accumulated
+// - The result may differ from the actual settlement
+// - Check Status and ErrMsg of each row, a failed row does not fail the call
+func (s *Service) TrialCalculate(req *TrialCalculateRequest) ([]TrialResult, error) {
+	return s.TrialCalculateContext(context.Background(), req)
+}
+
+// TrialCalculateContext is like TrialCalculate but carries the context for cancellation and deadlines.
+func (s *Service) TrialCalculateContext(ctx context.Context, req *TrialCalculateRequest) ([]TrialResult, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.ProviderId == 0 {
+		return nil, fmt.Errorf("providerId is required")
+	}
+	if len(req.UserList) == 0 {
+		return nil, fmt.Errorf("userList cannot be empty")
+	}
+	if len(req.UserList) > MaxTrialUsers {
+		return nil, fmt.Errorf("userList cannot exceed %d users", MaxTrialUsers)
+	}
+
+	idCards := make(map[string]bool, len(req.UserList))
+	for i, user := range req.UserList {
+		if user.Name == "" {
+			return nil, fmt.Errorf("userList[%d].name is required", i)
+		}
+		if user.IdCardNo == "" {
+			return nil, fmt.Errorf("userList[%d].idCardNo is required", i)
+		}
+		if user.Amt <= 0 {
+			return nil, fmt.Errorf("userList[%d].amt must be positive", i)
+		}
+		if idCards[user.IdCardNo] {
+			return nil, fmt.Errorf("userList[%d].idCardNo is duplicated", i)
+		}
+		idCards[user.IdCardNo] = true
+	}
+
+	// Call API with function code 6006
+	respData, err := s.client.DoContext(ctx, cores.FunCodeTrialCalculate, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp []TrialResult
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// TrialCalculatePayment calculates the net pay of the payees of a batch payment before submitting it.
+//
+// Amounts are aggregated per payee (by ID card) and calculated forward, in chunks of
+// MaxTrialUsers. MerOrderId is kept only for payees with a single item. req.QuotaCheck must be off.
+func (s *Service) TrialCalculatePayment(req *PaymentRequest) ([]TrialResult, error) {
+	return s.TrialCalculatePaymentContext(context.Background(), req)
+}
+
+// TrialCalculatePaymentContext is like TrialCalculatePayment but carries the context for cancellation and deadlines.
+func (s *Service) TrialCalculatePaymentContext(ctx context.Context, req *PaymentRequest) ([]TrialResult, error) {
+	// Validate request
+	if err := validatePaymentRequest(req); err != nil {
+		return nil, err
+	}
+	if req.QuotaCheck != QuotaCheckOff {
+		return nil, fmt.Errorf("quotaCheck is not supported for trial calculation")
+	}
+
+	var users []TrialUser
+	index := make(map[string]int)
+	for _, item := range req.PayItems {
+		if i, ok := index[item.IdCard]; ok {
+			users[i].Amt += item.Amt
+			users[i].MerOrderId = ""
+			continue
+		}
+		index[item.IdCard] = len(users)
+		users = append(users, TrialUser{
+			MerOrderId: item.MerOrderId,
+			Name:       item.PayeeName,
+			IdCardNo:   item.IdCard,
+			Amt:        item.Amt,
+		})
+	}
+
+	results := make([]TrialResult, 0, len(users))
+	for start := 0; start < len(users); start += MaxTrialUsers {
+		end := min(start+MaxTrialUsers, len(users))
+		chunk, err := s.TrialCalculateContext(ctx, &TrialCalculateRequest{
+			ProviderId: req.ProviderId,
+			UserList:   users[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, chunk...)
+	}
+
+	return results, nil
+}
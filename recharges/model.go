@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recharges
+
+// RechargeState represents the recharge transaction state.
+type RechargeState string
+
+const (
+	RechargeStateProcessing RechargeState = "PROCESSING" // indicates the recharge is being processed
+	RechargeStateSuccess    RechargeState = "SUCCESS"    // indicates the recharge succeeded
+	RechargeStateFail       RechargeState = "FAIL"       // indicates the recharge failed
+)
+
+// RechargeResult represents the result of a recharge query.
+type RechargeResult struct {
+	ProviderId        int64         `json:"providerId"`         // the service provider ID
+	ProviderName      string        `json:"providerName"`       // the service provider name
+	RechargeState     RechargeState `json:"rechargeState"`      // the recharge state
+	AccountingAmt     int64         `json:"accountingAmt"`      // the credited amount in fen
+	RechargeAmt       int64         `json:"rechargeAmt"`        // the recharge amount in fen
+	FeeAmt            int64         `json:"feeAmt"`             // the recharge fee in fen
+	EnterpriseOrderNo string        `json:"enterpriseOrderNo"`  // the merchant recharge order number
+	OrderNo           string        `json:"orderNo"`            // the platform recharge order number
+	CreateTime        string        `json:"createTime"`         // the creation time
+	UpdateTime        string        `json:"updateTime"`         // the completion time
+	ErrorMsg          string        `json:"errorMsg,omitempty"` // the failure reason if applicable
+}
+
+// RechargeCallbackResult represents the recharge result notified via the notifyUrl of ApplyRecharge.
+type RechargeCallbackResult struct {
+	ProviderId        int64         `json:"providerId"`        // the service provider ID
+	ProviderName      string        `json:"providerName"`      // the service provider name
+	EnterpriseOrderNo string        `json:"enterpriseOrderNo"` // the merchant recharge order number
+	OrderNo           string        `json:"orderNo"`           // the platform recharge order number
+	RechargeAmt       int64         `json:"rechargeAmt"`       // the recharge amount in fen (actually transferred by the merchant)
+	FeeAmt            int64         `json:"feeAmt"`            // the recharge fee in fen
+	AccountingAmt     int64         `json:"accountingAmt"`     // the credited amount in fen
+	RechargeState     RechargeState `json:"rechargeState"`     // the recharge state
+	BankRemark        string        `json:"bankRemark"`        // the bank transfer remark
+	CreateTime        string        `json:"createTime"`        // the creation time
+	UpdateTime        string        `json:"updateTime"`        // the completion time
+	ErrMsg            string        `json:"errMsg,omitempty"`  // the failure reason if applicable
+}
+
+// RechargeRecord represents a successful recharge record with its bank account details.
+type RechargeRecord struct {
+	ProviderId        int64         `json:"providerId"`                // the service provider ID
+	ProviderName      string        `json:"providerName"`              // the service provider name
+	EnterpriseOrderNo string        `json:"enterpriseOrderNo"`         // the merchant recharge order number
+	OrderNo           string        `json:"orderNo"`                   // the platform recharge order number
+	RechargeAmt       int64         `json:"rechargeAmt"`               // the recharge amount in fen (actually transferred by the merchant)
+	FeeAmt            int64         `json:"feeAmt"`                    // the recharge fee in fen
+	AccountingAmt     int64         `json:"accountingAmt"`             // the credited amount in fen
+	RechargeState     RechargeState `json:"rechargeState"`             // the recharge state
+	BankRemark        string        `json:"bankRemark,omitempty"`      // the bank transfer remark
+	ReceiveBankNo     string        `json:"receiveBankNo,omitempty"`   // the payee bank account number
+	ReceiveBankName   string        `json:"receiveBankName,omitempty"` // the payee bank account name
+	PayBankName       string        `json:"payBankName,omitempty"`     // the payer bank account name
+	PayBankNo         string        `json:"payBankNo,omitempty"`       // the payer bank account number
+	CreateTime        string        `json:"createTime"`                // the creation time
+	UpdateTime        string        `json:"updateTime"`                // the completion time
+	ErrMsg            string        `json:"errMsg,omitempty"`          // the failure reason if applicable
+}
+
+// MerchantRechargeCallbackResult represents the recharge result notified to the callback URL
+// configured in the merchant portal, which carries the payer and payee bank account details.
+type MerchantRechargeCallbackResult struct {
+	RechargeRecord
+}
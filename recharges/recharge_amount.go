@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recharges
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// RechargeAmountQueryRequest represents the request for querying the rechargeable amount.
+type RechargeAmountQueryRequest struct {
+	SubAccNo string `json:"subAccNo"` // the bank electronic sub-account opened on the platform
+}
+
+// RechargeAmountQueryResponse represents the response for rechargeable amount query.
+type RechargeAmountQueryResponse struct {
+	AvailableRechargeAmt int64  `json:"availableRechargeAmt"` // the rechargeable amount in fen
+	SubAccAmt            string `json:"subAccAmt"`            // the electronic sub-account balance in fen
+	SubAccNo             string `json:"subAccNo"`             // the bank electronic sub-account
+}
+
+// QueryRechargeAmount queries the amount available for profit-sharing.
+//
+// Note: The rechargeable amount is the electronic sub-account balance minus the amount
+// of recharges still being processed.
+func (s *Service) QueryRechargeAmount(req *RechargeAmountQueryRequest) (*RechargeAmountQueryResponse, error) {
+	return s.QueryRechargeAmountContext(context.Background(), req)
+}
+
+// QueryRechargeAmountContext is like QueryRechargeAmount but carries the context for cancellation and deadlines.
+func (s *Service) QueryRechargeAmountContext(ctx context.Context, req *RechargeAmountQueryRequest) (*RechargeAmountQueryResponse, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.SubAccNo == "" {
+		return nil, fmt.Errorf("subAccNo is required")
+	}
+
+	// Call API with function code 6019
+	respData, err := s.client.DoContext(ctx, cores.FunCodeRechargeAmount, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp RechargeAmountQueryResponse
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
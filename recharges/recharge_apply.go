@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recharges
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// RechargeApplyRequest represents the request for applying a recharge (profit-sharing).
+type RechargeApplyRequest struct {
+	ProviderId        int64  `json:"providerId"`          // the service provider ID
+	SubAccNo          string `json:"subAccNo"`            // the bank electronic sub-account opened on the platform
+	RechargeAmt       int64  `json:"rechargeAmt"`         // the recharge amount in fen
+	EnterpriseOrderNo string `json:"enterpriseOrderNo"`   // the merchant recharge order number
+	NotifyUrl         string `json:"notifyUrl,omitempty"` // the callback URL for the recharge result
+}
+
+// RechargeApplyResponse represents the response for recharge application.
+type RechargeApplyResponse struct {
+	ProviderId        int64         `json:"providerId"`              // the service provider ID
+	EnterpriseOrderNo string        `json:"enterpriseOrderNo"`       // the merchant recharge order number
+	OrderNo           string        `json:"orderNo"`                 // the platform recharge order number
+	RechargeAmt       string        `json:"rechargeAmt"`             // the recharge amount in fen
+	FeeAmt            string        `json:"feeAmt,omitempty"`        // the recharge fee in fen
+	AccountingAmt     int64         `json:"accountingAmt,omitempty"` // the credited amount in fen (rechargeAmt - feeAmt)
+	RechargeState     RechargeState `json:"rechargeState,omitempty"` // the recharge state
+	CreateTime        string        `json:"createTime,omitempty"`    // the creation time
+	UpdateTime        string        `json:"updateTime,omitempty"`    // the completion time
+	ErrMsg            string        `json:"errMsg,omitempty"`        // the failure reason if applicable
+}
+
+// ApplyRecharge applies for a recharge (profit-sharing) from the electronic sub-account
+// to the service provider account.
+//
+// IMPORTANT NOTES:
+// - The platform limits this interface to one call per minute per merchant
+// - The result is only notified on success; use QueryRechargeResult to confirm the final state
+func (s *Service) ApplyRecharge(req *RechargeApplyRequest) (*RechargeApplyResponse, error) {
+	return s.ApplyRechargeContext(context.Background(), req)
+}
+
+// ApplyRechargeContext is like ApplyRecharge but carries the context for cancellation and deadlines.
+func (s *Service) ApplyRechargeContext(ctx context.Context, req *RechargeApplyRequest) (*RechargeApplyResponse, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.ProviderId == 0 {
+		return nil, fmt.Errorf("providerId is required")
+	}
+	if req.SubAccNo == "" {
+		return nil, fmt.Errorf("subAccNo is required")
+	}
+	if req.RechargeAmt <= 0 {
+		return nil, fmt.Errorf("rechargeAmt must be greater than 0 fen")
+	}
+	if req.EnterpriseOrderNo == "" {
+		return nil, fmt.Errorf("enterpriseOrderNo is required")
+	}
+
+	// Call API with function code 6020
+	respData, err := s.client.DoContext(ctx, cores.FunCodeRechargeApply, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp RechargeApplyResponse
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recharges
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ParseRechargeCallback parses and validates the recharge result callback request
+// sent to the notifyUrl of ApplyRecharge. It takes the raw JSON body of the callback request.
+//
+// Note: Only successful recharges are notified.
+func (s *Service) ParseRechargeCallback(body []byte) (*RechargeCallbackResult, error) {
+	// Verify and decrypt the notification
+	decryptedData, err := s.client.VerifyAndDecryptNotification(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if decryptedData == "" {
+		return nil, fmt.Errorf("empty callback data")
+	}
+
+	// Unmarshal decrypted data
+	var callback RechargeCallbackResult
+	if err := json.Unmarshal([]byte(decryptedData), &callback); err != nil {
+		return nil, fmt.Errorf("failed to parse callback data: %w", err)
+	}
+
+	return &callback, nil
+}
+
+// ParseMerchantRechargeCallback parses and validates the recharge callback request sent to the
+// callback URL configured in the merchant portal. It takes the raw JSON body of the callback request.
+//
+// Note: Only successful recharges are notified. Reply with cores.NotificationAckBody()
+// once the recharge is credited, otherwise the platform resends the notification.
+func (s *Service) ParseMerchantRechargeCallback(body []byte) (*MerchantRechargeCallbackResult, error) {
+	// Verify and decrypt the notification
+	decryptedData, err := s.client.VerifyAndDecryptNotification(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if decryptedData == "" {
+		return nil, fmt.Errorf("empty callback data")
+	}
+
+	// Unmarshal decrypted data
+	var callback MerchantRechargeCallbackResult
+	if err := json.Unmarshal([]byte(decryptedData), &callback); err != nil {
+		return nil, fmt.Errorf("failed to parse callback data: %w", err)
+	}
+
+	return &callback, nil
+}
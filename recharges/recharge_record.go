@@ -0,0 +1,152 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recharges
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// MaxRechargeRecordDays is the maximum number of days covered by a single recharge record query.
+const MaxRechargeRecordDays = 31
+
+// rechargeRecordQueryRequest represents the request for querying recharge records within one window.
+type rechargeRecordQueryRequest struct {
+	ProviderId int64  `json:"providerId"` // the service provider ID
+	StartDate  string `json:"startDate"`  // the recharge start date (format: yyyy-MM-dd)
+	EndDate    string `json:"endDate"`    // the recharge end date (format: yyyy-MM-dd)
+}
+
+// QueryRechargeRecords queries the successful recharge records between start and end (both inclusive).
+//
+// The platform limits each query to 31 days, so longer ranges are split into consecutive
+// windows that are queried in order. The merged records are deduplicated by OrderNo.
+// Only the date part of start and end in China Standard Time is used.
+func (s *Service) QueryRechargeRecords(providerId int64, start, end time.Time) ([]RechargeRecord, error) {
+	return s.QueryRechargeRecordsContext(context.Background(), providerId, start, end)
+}
+
+// QueryRechargeRecordsContext is like QueryRechargeRecords but carries the context for cancellation and deadlines.
+func (s *Service) QueryRechargeRecordsContext(ctx context.Context, providerId int64, start, end time.Time) ([]RechargeRecord, error) {
+	// Validate request
+	if providerId == 0 {
+		return nil, fmt.Errorf("providerId is required")
+	}
+	if start.IsZero() || end.IsZero() {
+		return nil, fmt.Errorf("start and end are required")
+	}
+
+	windows := splitDateWindows(start, end, MaxRechargeRecordDays)
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("start must not be after end")
+	}
+
+	var records []RechargeRecord
+	seen := make(map[string]bool)
+	for _, w := range windows {
+		list, err := s.queryRechargeRecords(ctx, &rechargeRecordQueryRequest{
+			ProviderId: providerId,
+			StartDate:  w[0].Format(time.DateOnly),
+			EndDate:    w[1].Format(time.DateOnly),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query recharge records from %s to %s: %w",
+				w[0].Format(time.DateOnly), w[1].Format(time.DateOnly), err)
+		}
+
+		for _, r := range list {
+			if r.OrderNo != "" {
+				if seen[r.OrderNo] {
+					continue
+				}
+				seen[r.OrderNo] = true
+			}
+			records = append(records, r)
+		}
+	}
+
+	return records, nil
+}
+
+// queryRechargeRecords queries the recharge records within a single window of at most 31 days.
+func (s *Service) queryRechargeRecords(ctx context.Context, req *rechargeRecordQueryRequest) ([]RechargeRecord, error) {
+	// Call API with function code 6018
+	respData, err := s.client.DoContext(ctx, cores.FunCodeRechargeRecord, req)
+	if err != nil {
+		// No recharge in the window is not a failure
+		if errors.Is(err, cores.ErrApiRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, nil
+	}
+
+	// Accept both a bare list of records and a rechargeRecordList object
+	data := bytes.TrimSpace([]byte(respData))
+	if len(data) > 0 && data[0] == '{' {
+		var resp struct {
+			RechargeRecordList []RechargeRecord `json:"rechargeRecordList"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return resp.RechargeRecordList, nil
+	}
+
+	// Unmarshal decrypted response
+	var records []RechargeRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return records, nil
+}
+
+// splitDateWindows splits the dates from start to end (both inclusive) into consecutive
+// [first, last] windows spanning at most maxDays days each.
+func splitDateWindows(start, end time.Time, maxDays int) [][2]time.Time {
+	first := truncateDate(start)
+	last := truncateDate(end)
+
+	var windows [][2]time.Time
+	for !first.After(last) {
+		windowEnd := first.AddDate(0, 0, maxDays-1)
+		if windowEnd.After(last) {
+			windowEnd = last
+		}
+		windows = append(windows, [2]time.Time{first, windowEnd})
+		first = windowEnd.AddDate(0, 0, 1)
+	}
+	return windows
+}
+
+// truncateDate returns midnight of the date of t in China Standard Time.
+func truncateDate(t time.Time) time.Time {
+	y, m, d := t.In(cores.Location).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, cores.Location)
+}
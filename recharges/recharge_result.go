@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recharges
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// RechargeResultQueryRequest represents the request for querying recharge results.
+type RechargeResultQueryRequest struct {
+	EnterpriseOrderNo string `json:"enterpriseOrderNo,omitempty"` // the merchant recharge order number
+	OrderNo           string `json:"orderNo,omitempty"`           // the platform recharge order number
+}
+
+// RechargeResultQueryResponse represents the response for recharge result query.
+type RechargeResultQueryResponse struct {
+	RechargeRecordList []RechargeResult `json:"rechargeRecordList"` // the recharge records
+}
+
+// QueryRechargeResult queries the result of recharge applications.
+//
+// Note: At least one of EnterpriseOrderNo and OrderNo is required.
+func (s *Service) QueryRechargeResult(req *RechargeResultQueryRequest) (*RechargeResultQueryResponse, error) {
+	return s.QueryRechargeResultContext(context.Background(), req)
+}
+
+// QueryRechargeResultContext is like QueryRechargeResult but carries the context for cancellation and deadlines.
+func (s *Service) QueryRechargeResultContext(ctx context.Context, req *RechargeResultQueryRequest) (*RechargeResultQueryResponse, error) {
+	// Validate request
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.EnterpriseOrderNo == "" && req.OrderNo == "" {
+		return nil, fmt.Errorf("enterpriseOrderNo or orderNo is required")
+	}
+
+	// Call API with function code 6021
+	respData, err := s.client.DoContext(ctx, cores.FunCodeRechargeResult, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Accept both the documented rechargeRecordList object and a bare list of records
+	data := bytes.TrimSpace([]byte(respData))
+	if len(data) > 0 && data[0] == '[' {
+		var records []RechargeResult
+		if err := json.Unmarshal(data, &records); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return &RechargeResultQueryResponse{RechargeRecordList: records}, nil
+	}
+
+	// Unmarshal decrypted response
+	var resp RechargeResultQueryResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
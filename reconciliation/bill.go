@@ -0,0 +1,171 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reconciliation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// MaxBillSize is the maximum size of a downloaded reconciliation file in bytes.
+const MaxBillSize = 64 << 20
+
+// Bill file errors
+var (
+	ErrBillNotReady = fmt.Errorf("bill file is not ready until 06:00 of the next day")
+	ErrBillExpired  = fmt.Errorf("bill file is only available for the last six months")
+)
+
+// BillQueryRequest represents the request for querying the reconciliation file.
+type BillQueryRequest struct {
+	BillDate string `json:"billDate"` // the transaction success date (format: yyyy-MM-dd)
+}
+
+// BillQueryResponse represents the response for reconciliation file query.
+type BillQueryResponse struct {
+	FilePath string `json:"filePath"` // the reconciliation file download URL
+	BillDate string `json:"billDate"` // the transaction success date (format: yyyy-MM-dd)
+}
+
+// Bill represents the reconciliation statement of a day.
+type Bill struct {
+	BillDate string       // the transaction success date (format: yyyy-MM-dd)
+	FilePath string       // the reconciliation file download URL
+	Records  []BillRecord // the successful orders of the day
+}
+
+// QueryBillFile queries the download URL of the reconciliation file of the given date.
+//
+// IMPORTANT NOTES:
+// - The file only contains successful orders and is generated T+1, available after 06:00 of the next day
+// - Only files of the last six months can be downloaded
+func (s *Service) QueryBillFile(date time.Time) (*BillQueryResponse, error) {
+	return s.QueryBillFileContext(context.Background(), date)
+}
+
+// QueryBillFileContext is like QueryBillFile but carries the context for cancellation and deadlines.
+func (s *Service) QueryBillFileContext(ctx context.Context, date time.Time) (*BillQueryResponse, error) {
+	// Validate request
+	if date.IsZero() {
+		return nil, fmt.Errorf("billDate is required")
+	}
+	if err := checkBillDate(date, time.Now()); err != nil {
+		return nil, err
+	}
+
+	req := &BillQueryRequest{
+		BillDate: date.In(cores.Location).Format(time.DateOnly),
+	}
+
+	// Call API with function code 6004
+	respData, err := s.client.DoContext(ctx, cores.FunCodeBillDownload, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle empty response
+	if respData == "" {
+		return nil, fmt.Errorf("empty response data")
+	}
+
+	// Unmarshal decrypted response
+	var resp BillQueryResponse
+	if err := json.Unmarshal([]byte(respData), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// DownloadBill downloads the reconciliation file of the given date and parses its records.
+func (s *Service) DownloadBill(date time.Time) (*Bill, error) {
+	return s.DownloadBillContext(context.Background(), date)
+}
+
+// DownloadBillContext is like DownloadBill but carries the context for cancellation and deadlines.
+func (s *Service) DownloadBillContext(ctx context.Context, date time.Time) (*Bill, error) {
+	resp, err := s.QueryBillFileContext(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if resp.FilePath == "" {
+		return nil, fmt.Errorf("%w: %s", cores.ErrApiBillFileNotFound, date.In(cores.Location).Format(time.DateOnly))
+	}
+
+	// Download bill file
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, resp.FilePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download bill file: %w", err)
+	}
+	httpResp, err := s.client.HTTPClient().Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download bill file: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download bill file: HTTP %d", httpResp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(httpResp.Body, MaxBillSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download bill file: %w", err)
+	}
+	if len(data) > MaxBillSize {
+		return nil, fmt.Errorf("failed to download bill file: larger than %d bytes", MaxBillSize)
+	}
+
+	records, err := ParseBill(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bill{
+		BillDate: resp.BillDate,
+		FilePath: resp.FilePath,
+		Records:  records,
+	}, nil
+}
+
+// checkBillDate checks the bill file of date can be downloaded at now.
+func checkBillDate(date, now time.Time) error {
+	y, m, d := date.In(cores.Location).Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, cores.Location)
+
+	if now.Before(day.AddDate(0, 0, 1).Add(6 * time.Hour)) {
+		return fmt.Errorf("%w: %s", ErrBillNotReady, day.Format(time.DateOnly))
+	}
+
+	// Step back from the first of the month so that e.g. Aug 31 does not normalise to Mar 3,
+	// then clamp the day to the length of the target month
+	y, m, d = now.In(cores.Location).Date()
+	first := time.Date(y, m, 1, 0, 0, 0, 0, cores.Location).AddDate(0, -6, 0)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day.Before(first.AddDate(0, 0, min(d, lastDay)-1)) {
+		return fmt.Errorf("%w: %s", ErrBillExpired, day.Format(time.DateOnly))
+	}
+
+	return nil
+}
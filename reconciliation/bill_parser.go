@@ -0,0 +1,486 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reconciliation
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// BillRecord represents a successful order in the reconciliation file.
+type BillRecord struct {
+	MerBatchId string            // the merchant batch number
+	MerOrderId string            // the merchant order ID
+	OrderNo    string            // the platform order number
+	PayeeName  string            // the payee's name
+	Amt        int64             // the payment amount in fen
+	Fee        int64             // the service fee in fen
+	PayTime    time.Time         // the payment time (creation time for API payments)
+	Columns    map[string]string // all cell values keyed by the column header
+}
+
+// billColumn maps a record field to the headers identifying its column.
+// Headers are matched exactly first, ignoring a unit suffix like (元), then by keyword,
+// each in priority order.
+type billColumn struct {
+	field    string   // the field name used in errors
+	names    []string // the exact header names
+	keywords []string // the header keywords
+	exclude  []string // the keywords excluding a header from keyword matching
+}
+
+var (
+	billColumnMerBatchId = billColumn{field: "merBatchId", names: []string{"商户批次号", "客户批次号", "批次号"}, keywords: []string{"批次号"}}
+	billColumnMerOrderId = billColumn{field: "merOrderId", names: []string{"商户订单号", "客户订单号"}, keywords: []string{"商户订单号", "客户订单号"}}
+	billColumnOrderNo    = billColumn{field: "orderNo", names: []string{"平台订单号", "订单流水号", "订单号"}, keywords: []string{"平台订单号", "订单流水号", "订单号"}, exclude: []string{"商户", "客户"}}
+	billColumnPayeeName  = billColumn{field: "payeeName", names: []string{"收款人姓名", "收款人", "姓名"}, keywords: []string{"姓名"}}
+	billColumnAmt        = billColumn{field: "amt", names: []string{"付款金额", "下发金额", "金额"}, keywords: []string{"付款金额", "下发金额"}, exclude: []string{"费", "税", "到账"}}
+	billColumnFee        = billColumn{field: "fee", names: []string{"服务费", "管理费", "手续费"}, keywords: []string{"服务费", "管理费", "手续费"}, exclude: []string{"率"}}
+	billColumnPayTime    = billColumn{field: "payTime", names: []string{"付款时间", "支付时间", "交易时间"}, keywords: []string{"付款时间", "支付时间", "交易时间"}}
+)
+
+// ParseBill parses the records of a reconciliation file in Excel (xlsx) format.
+//
+// Columns are located by their header, so the parser tolerates extra or reordered columns.
+// Amounts are converted to fen; columns whose header does not mention 分 are treated as yuan.
+func ParseBill(r io.ReaderAt, size int64) ([]BillRecord, error) {
+	rows, err := readXLSXRows(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// Locate the header row
+	headerIndex := -1
+	for i, row := range rows {
+		for _, cell := range row {
+			if strings.Contains(cell, "订单号") {
+				headerIndex = i
+				break
+			}
+		}
+		if headerIndex >= 0 {
+			break
+		}
+	}
+	if headerIndex < 0 {
+		return nil, fmt.Errorf("failed to parse bill file: header row not found")
+	}
+
+	header := rows[headerIndex]
+	columns := []billColumn{
+		billColumnMerBatchId, billColumnMerOrderId, billColumnOrderNo,
+		billColumnPayeeName, billColumnAmt, billColumnFee, billColumnPayTime,
+	}
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		if indexes[i], err = findBillColumn(header, column); err != nil {
+			return nil, err
+		}
+	}
+	merBatchIdCol, merOrderIdCol, orderNoCol := indexes[0], indexes[1], indexes[2]
+	payeeNameCol, amtCol, feeCol, payTimeCol := indexes[3], indexes[4], indexes[5], indexes[6]
+
+	if merOrderIdCol < 0 && orderNoCol < 0 {
+		return nil, fmt.Errorf("failed to parse bill file: order number column not found")
+	}
+	if amtCol < 0 {
+		return nil, fmt.Errorf("failed to parse bill file: amount column not found")
+	}
+	if payTimeCol < 0 {
+		return nil, fmt.Errorf("failed to parse bill file: pay time column not found")
+	}
+
+	var records []BillRecord
+	for i, row := range rows[headerIndex+1:] {
+		record := BillRecord{
+			MerBatchId: cellAt(row, merBatchIdCol),
+			MerOrderId: cellAt(row, merOrderIdCol),
+			OrderNo:    cellAt(row, orderNoCol),
+			PayeeName:  cellAt(row, payeeNameCol),
+			Columns:    make(map[string]string, len(header)),
+		}
+
+		// Skip blank and summary rows
+		if isBillSummaryRow(record, orderNoCol >= 0) {
+			continue
+		}
+
+		for j, name := range header {
+			if name != "" {
+				record.Columns[name] = cellAt(row, j)
+			}
+		}
+
+		lineNo := headerIndex + i + 2
+		if record.Amt, err = parseBillAmount(cellAt(row, amtCol), header, amtCol); err != nil {
+			return nil, fmt.Errorf("failed to parse bill file: row %d: %w", lineNo, err)
+		}
+		if record.Fee, err = parseBillAmount(cellAt(row, feeCol), header, feeCol); err != nil {
+			return nil, fmt.Errorf("failed to parse bill file: row %d: %w", lineNo, err)
+		}
+		if record.PayTime, err = parseBillTime(cellAt(row, payTimeCol)); err != nil {
+			return nil, fmt.Errorf("failed to parse bill file: row %d: %w", lineNo, err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// isBillSummaryRow reports whether the record is a blank or summary row rather than an order.
+// Every order carries a platform order number when the file has that column.
+func isBillSummaryRow(record BillRecord, hasOrderNo bool) bool {
+	if hasOrderNo {
+		return record.OrderNo == ""
+	}
+	return record.MerOrderId == "" ||
+		strings.HasPrefix(record.MerOrderId, "合计") ||
+		strings.HasPrefix(record.MerOrderId, "总计")
+}
+
+// findBillColumn returns the index of the header matching the column, or -1 if none.
+// It fails if several headers match at the same priority.
+func findBillColumn(header []string, column billColumn) (int, error) {
+	for _, name := range column.names {
+		matches := matchBillHeaders(header, func(h string) bool { return billHeaderName(h) == name })
+		if col, err := uniqueBillColumn(header, column, matches); col >= 0 || err != nil {
+			return col, err
+		}
+	}
+
+	for _, keyword := range column.keywords {
+		matches := matchBillHeaders(header, func(h string) bool {
+			if !strings.Contains(h, keyword) {
+				return false
+			}
+			for _, ex := range column.exclude {
+				if strings.Contains(h, ex) {
+					return false
+				}
+			}
+			return true
+		})
+		if col, err := uniqueBillColumn(header, column, matches); col >= 0 || err != nil {
+			return col, err
+		}
+	}
+
+	return -1, nil
+}
+
+// matchBillHeaders returns the indexes of the headers matching the predicate.
+func matchBillHeaders(header []string, match func(h string) bool) []int {
+	var matches []int
+	for i, h := range header {
+		if match(strings.TrimSpace(h)) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// uniqueBillColumn returns the only matched column, -1 if none, or an error if ambiguous.
+func uniqueBillColumn(header []string, column billColumn, matches []int) (int, error) {
+	switch len(matches) {
+	case 0:
+		return -1, nil
+	case 1:
+		return matches[0], nil
+	default:
+		return -1, fmt.Errorf("failed to parse bill file: ambiguous %s column: %q and %q",
+			column.field, header[matches[0]], header[matches[1]])
+	}
+}
+
+// billHeaderName returns the header without spaces and its unit suffix, e.g. 付款金额 for 付款金额(元).
+func billHeaderName(h string) string {
+	h = strings.TrimSpace(h)
+	if i := strings.IndexAny(h, "(（"); i > 0 {
+		h = strings.TrimSpace(h[:i])
+	}
+	return h
+}
+
+// cellAt returns the trimmed cell value at index i, or "" if out of range.
+func cellAt(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// parseBillAmount converts an amount cell to fen, based on the unit in the column header.
+func parseBillAmount(value string, header []string, col int) (int64, error) {
+	value = strings.ReplaceAll(value, ",", "")
+	if value == "" {
+		return 0, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+
+	if strings.Contains(header[col], "分") {
+		return int64(math.Round(f)), nil
+	}
+	return int64(math.Round(f * 100)), nil
+}
+
+// excelEpoch is the base date of Excel serial date numbers.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, cores.Location)
+
+// parseBillTime parses a time cell, either formatted text or an Excel serial date number.
+func parseBillTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{time.DateTime, "2006/01/02 15:04:05", time.DateOnly, "2006/01/02"} {
+		if t, err := time.ParseInLocation(layout, value, cores.Location); err == nil {
+			return t, nil
+		}
+	}
+
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		days := math.Floor(f)
+		seconds := math.Round((f - days) * 24 * 60 * 60)
+		return excelEpoch.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
+
+// xlsxSharedStrings is the shared string table of a workbook (xl/sharedStrings.xml).
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+// xlsxRichText is a plain or rich text value.
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String returns the concatenated text.
+func (t *xlsxRichText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	b.WriteString(t.Text)
+	for _, r := range t.Runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
+
+// xlsxWorksheet is a worksheet of a workbook (xl/worksheets/sheetN.xml).
+type xlsxWorksheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string        `xml:"r,attr"`
+			Type   string        `xml:"t,attr"`
+			Value  string        `xml:"v"`
+			Inline *xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// xlsxWorkbook is the workbook part listing the sheets in order (xl/workbook.xml).
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxRelationships is the relationship part of the workbook (xl/_rels/workbook.xml.rels).
+type xlsxRelationships struct {
+	Relationships []struct {
+		Id     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+const (
+	xlsxMaxColumns  = 16384     // the maximum number of columns of a worksheet (XFD)
+	xlsxMaxPartSize = 256 << 20 // the maximum uncompressed size of a workbook part in bytes
+)
+
+// readXLSXRows reads the cell values of the first worksheet of an xlsx workbook.
+func readXLSXRows(r io.ReaderAt, size int64) ([][]string, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bill file: unsupported format, only xlsx is supported: %w", err)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(f, &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetName, err := firstXLSXSheet(files)
+	if err != nil {
+		return nil, err
+	}
+	sheetFile, ok := files[sheetName]
+	if !ok {
+		return nil, fmt.Errorf("failed to parse bill file: worksheet %s not found", sheetName)
+	}
+
+	var sheet xlsxWorksheet
+	if err := decodeXLSXPart(sheetFile, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		var values []string
+		for i, c := range row.Cells {
+			col, err := xlsxColumnIndex(c.Ref)
+			if err != nil {
+				return nil, err
+			}
+			if col < 0 {
+				col = i
+			}
+			if col >= xlsxMaxColumns {
+				return nil, fmt.Errorf("failed to parse bill file: too many cells in a row")
+			}
+			for len(values) <= col {
+				values = append(values, "")
+			}
+
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(c.Value)
+				if err != nil || idx < 0 || idx >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("failed to parse bill file: invalid shared string index %q", c.Value)
+				}
+				values[col] = sharedStrings.Items[idx].String()
+			case "inlineStr":
+				if c.Inline != nil {
+					values[col] = c.Inline.String()
+				}
+			default:
+				values[col] = c.Value
+			}
+		}
+		rows = append(rows, values)
+	}
+
+	return rows, nil
+}
+
+// firstXLSXSheet returns the part name of the first worksheet, resolved through the workbook
+// and its relationships since part names do not tell the sheet order.
+func firstXLSXSheet(files map[string]*zip.File) (string, error) {
+	workbookFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("failed to parse bill file: workbook not found")
+	}
+	var workbook xlsxWorkbook
+	if err := decodeXLSXPart(workbookFile, &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("failed to parse bill file: no worksheet found")
+	}
+
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return "", fmt.Errorf("failed to parse bill file: workbook relationships not found")
+	}
+	var rels xlsxRelationships
+	if err := decodeXLSXPart(relsFile, &rels); err != nil {
+		return "", err
+	}
+
+	rid := workbook.Sheets[0].RID
+	for _, rel := range rels.Relationships {
+		if rel.Id != rid {
+			continue
+		}
+		// Targets are relative to xl/ unless absolute within the package
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return "", fmt.Errorf("failed to parse bill file: worksheet %s not found", workbook.Sheets[0].Name)
+}
+
+// decodeXLSXPart decodes an XML part of the workbook, reading at most xlsxMaxPartSize bytes
+// so a highly compressed part cannot exhaust memory.
+func decodeXLSXPart(f *zip.File, v any) error {
+	if f.UncompressedSize64 > xlsxMaxPartSize {
+		return fmt.Errorf("failed to parse bill file: %s larger than %d bytes", f.Name, xlsxMaxPartSize)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to parse bill file: %w", err)
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(rc, xlsxMaxPartSize)).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse bill file: %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// xlsxColumnIndex returns the zero-based column index of a cell reference like "B3", or -1 if
+// the reference has no column. It fails on columns beyond the worksheet limit.
+func xlsxColumnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+		if col > xlsxMaxColumns {
+			return -1, fmt.Errorf("failed to parse bill file: invalid cell reference %q", ref)
+		}
+		n++
+	}
+	if n == 0 {
+		return -1, nil
+	}
+	return col - 1, nil
+}
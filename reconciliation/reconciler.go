@@ -0,0 +1,314 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reconciliation
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/payments"
+)
+
+// LedgerEntry represents a payment order recorded in the merchant's own ledger.
+type LedgerEntry struct {
+	MerBatchId string // the merchant batch number
+	MerOrderId string // the merchant order ID
+	OrderNo    int64  // the platform order number, optional; preferred for matching when set
+	Amt        int64  // the payment amount in fen
+	Fee        int64  // the service fee in fen
+	UserFee    int64  // the user's fee in fen
+	VaTax      int64  // the VAT tax amount in fen
+	VaAddTax   int64  // the VAT additional tax amount in fen
+}
+
+// Ledger provides the payment orders recorded by the merchant.
+type Ledger interface {
+	// LedgerEntries returns the payment orders of the batch that the merchant considers paid.
+	LedgerEntries(ctx context.Context, merBatchId string) ([]LedgerEntry, error)
+}
+
+// DiffType represents the kind of mismatch between the ledger and the platform.
+type DiffType string
+
+const (
+	DiffMissingLocal  DiffType = "MISSING_LOCAL"  // paid by the platform but absent from the ledger
+	DiffMissingRemote DiffType = "MISSING_REMOTE" // recorded in the ledger but not paid by the platform
+	DiffPending       DiffType = "PENDING"        // not final on the platform yet, reconcile again later
+	DiffAmount        DiffType = "AMOUNT"         // the payment amounts differ
+	DiffFee           DiffType = "FEE"            // the service fees differ
+	DiffTax           DiffType = "TAX"            // the taxes (userFee, vaTax or vaAddTax) differ
+)
+
+// Diff represents a mismatch between a ledger entry and the platform payment result.
+type Diff struct {
+	Type       DiffType              `json:"type"`            // the kind of mismatch
+	MerBatchId string                `json:"merBatchId"`      // the merchant batch number
+	MerOrderId string                `json:"merOrderId"`      // the merchant order ID
+	OrderNo    int64                 `json:"orderNo"`         // the platform order number, 0 if unknown
+	State      payments.PaymentState `json:"state"`           // the platform payment state
+	Field      string                `json:"field,omitempty"` // the mismatched field for amount, fee and tax diffs
+	Local      int64                 `json:"local"`           // the ledger value in fen
+	Remote     int64                 `json:"remote"`          // the platform value in fen
+}
+
+// Report represents the result of a reconciliation.
+type Report struct {
+	Batches int    `json:"batches"` // the number of reconciled batches
+	Matched int    `json:"matched"` // the number of orders matching on both sides
+	Diffs   []Diff `json:"diffs"`   // the mismatches found
+}
+
+// WriteJSON writes the report as JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes the diffs of the report as CSV with a header row.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"type", "merBatchId", "merOrderId", "orderNo", "state", "field", "local", "remote"}); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	for _, d := range r.Diffs {
+		err := cw.Write([]string{
+			string(d.Type),
+			d.MerBatchId,
+			d.MerOrderId,
+			strconv.FormatInt(d.OrderNo, 10),
+			strconv.Itoa(int(d.State)),
+			d.Field,
+			strconv.FormatInt(d.Local, 10),
+			strconv.FormatInt(d.Remote, 10),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// Reconciler matches the merchant ledger against the payment results of the platform.
+type Reconciler struct {
+	paymentService *payments.Service
+	ledger         Ledger
+}
+
+// NewReconciler creates a new reconciler.
+func NewReconciler(paymentService *payments.Service, ledger Ledger) *Reconciler {
+	return &Reconciler{
+		paymentService: paymentService,
+		ledger:         ledger,
+	}
+}
+
+// Reconcile reconciles the given batches, querying each of them via PaymentQuery.
+func (r *Reconciler) Reconcile(merBatchIds []string) (*Report, error) {
+	return r.ReconcileContext(context.Background(), merBatchIds)
+}
+
+// ReconcileContext is like Reconcile but carries the context for cancellation and deadlines.
+func (r *Reconciler) ReconcileContext(ctx context.Context, merBatchIds []string) (*Report, error) {
+	report := &Report{}
+
+	for _, merBatchId := range merBatchIds {
+		local, err := r.ledger.LedgerEntries(ctx, merBatchId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ledger entries of batch %s: %w", merBatchId, err)
+		}
+
+		var remote []payments.PaymentResult
+		resp, err := r.paymentService.PaymentQueryContext(ctx, &payments.PaymentQueryRequest{MerBatchId: merBatchId})
+		switch {
+		case err == nil:
+			remote = resp.QueryItems
+		case errors.Is(err, cores.ErrApiBatchNoNotFound), errors.Is(err, cores.ErrApiOrderNotFound):
+			// Unknown to the platform, every ledger entry is missing remotely
+		default:
+			return nil, fmt.Errorf("failed to query batch %s: %w", merBatchId, err)
+		}
+
+		diffs, matched := compareBatch(merBatchId, local, remote)
+		report.Batches++
+		report.Matched += matched
+		report.Diffs = append(report.Diffs, diffs...)
+	}
+
+	return report, nil
+}
+
+// CompareBatch compares the ledger entries of a batch with the platform payment results of it.
+//
+// Entries are matched by OrderNo when the ledger records it, otherwise by MerOrderId.
+// An entry whose OrderNo the platform does not know is reported as missing remotely.
+// Orders the platform reports as failed or cancelled count as not paid. Orders not final yet
+// (initialised, processing or awaiting confirmation) are reported as pending, whether or not
+// the ledger records them, and are not compared.
+func CompareBatch(merBatchId string, local []LedgerEntry, remote []payments.PaymentResult) []Diff {
+	diffs, _ := compareBatch(merBatchId, local, remote)
+	return diffs
+}
+
+// compareBatch compares a batch and returns the diffs and the number of matched orders.
+func compareBatch(merBatchId string, local []LedgerEntry, remote []payments.PaymentResult) ([]Diff, int) {
+	byOrderNo := make(map[int64]int, len(remote))
+	byMerOrderId := make(map[string]int, len(remote))
+	for i, p := range remote {
+		if p.OrderNo != 0 {
+			byOrderNo[p.OrderNo] = i
+		}
+		byMerOrderId[p.MerOrderId] = i
+	}
+
+	var diffs []Diff
+	matched := 0
+	used := make([]bool, len(remote))
+
+	for _, entry := range local {
+		// An OrderNo unknown to the platform is missing remotely, even if the MerOrderId is known,
+		// so the conflicting remote order surfaces as missing locally
+		var i int
+		var ok bool
+		if entry.OrderNo != 0 {
+			i, ok = byOrderNo[entry.OrderNo]
+		} else {
+			i, ok = byMerOrderId[entry.MerOrderId]
+		}
+
+		if ok && !used[i] && !isFinal(remote[i].State) {
+			used[i] = true
+			diffs = append(diffs, pendingDiff(merBatchId, remote[i], entry.Amt))
+			continue
+		}
+
+		if !ok || used[i] || !isPaid(remote[i].State) {
+			diff := Diff{
+				Type:       DiffMissingRemote,
+				MerBatchId: merBatchId,
+				MerOrderId: entry.MerOrderId,
+				OrderNo:    entry.OrderNo,
+				Local:      entry.Amt,
+			}
+			if ok && !used[i] {
+				used[i] = true
+				diff.OrderNo = remote[i].OrderNo
+				diff.State = remote[i].State
+			}
+			diffs = append(diffs, diff)
+			continue
+		}
+
+		used[i] = true
+		p := remote[i]
+
+		fields := []struct {
+			diffType DiffType
+			name     string
+			local    int64
+			remote   int64
+		}{
+			{DiffAmount, "amt", entry.Amt, p.Amt},
+			{DiffFee, "fee", entry.Fee, p.Fee},
+			{DiffTax, "userFee", entry.UserFee, p.UserFee},
+			{DiffTax, "vaTax", entry.VaTax, p.VaTax},
+			{DiffTax, "vaAddTax", entry.VaAddTax, p.VaAddTax},
+		}
+
+		mismatched := false
+		for _, f := range fields {
+			if f.local == f.remote {
+				continue
+			}
+			mismatched = true
+			diffs = append(diffs, Diff{
+				Type:       f.diffType,
+				MerBatchId: merBatchId,
+				MerOrderId: p.MerOrderId,
+				OrderNo:    p.OrderNo,
+				State:      p.State,
+				Field:      f.name,
+				Local:      f.local,
+				Remote:     f.remote,
+			})
+		}
+		if !mismatched {
+			matched++
+		}
+	}
+
+	for i, p := range remote {
+		if used[i] {
+			continue
+		}
+		if !isFinal(p.State) {
+			diffs = append(diffs, pendingDiff(merBatchId, p, 0))
+			continue
+		}
+		if !isPaid(p.State) {
+			continue
+		}
+		diffs = append(diffs, Diff{
+			Type:       DiffMissingLocal,
+			MerBatchId: merBatchId,
+			MerOrderId: p.MerOrderId,
+			OrderNo:    p.OrderNo,
+			State:      p.State,
+			Remote:     p.Amt,
+		})
+	}
+
+	return diffs, matched
+}
+
+// pendingDiff returns the diff of a platform order not final yet, with the ledger amount if recorded.
+func pendingDiff(merBatchId string, p payments.PaymentResult, local int64) Diff {
+	return Diff{
+		Type:       DiffPending,
+		MerBatchId: merBatchId,
+		MerOrderId: p.MerOrderId,
+		OrderNo:    p.OrderNo,
+		State:      p.State,
+		Local:      local,
+		Remote:     p.Amt,
+	}
+}
+
+// isPaid reports whether the platform has paid the order.
+func isPaid(state payments.PaymentState) bool {
+	return state == payments.PaymentStateSuccess
+}
+
+// isFinal reports whether the payment state of the order will not change anymore.
+func isFinal(state payments.PaymentState) bool {
+	return state == payments.PaymentStateSuccess || state == payments.PaymentStateFailed ||
+		state == payments.PaymentStateCancelled
+}
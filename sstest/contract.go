@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sstest
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/vogo/vservicesharesdk/accounts"
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/freelancers"
+)
+
+// balanceQuery handles the balance query (6003).
+func (s *Server) balanceQuery(ctx context.Context, reqData []byte) (any, error) {
+	var req accounts.BalanceQueryRequest
+	if err := decode(reqData, &req); err != nil {
+		return nil, err
+	}
+	if req.ProviderID == 0 {
+		return nil, cores.ErrApiServiceIdEmpty
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &accounts.BalanceQueryResponse{Balance: s.balance, ProviderID: req.ProviderID}, nil
+}
+
+// contractKey returns the key of a freelancer's contract with a provider.
+func contractKey(idCard string, providerId int64) string {
+	return idCard + ":" + strconv.FormatInt(providerId, 10)
+}
+
+// signContract handles the silent contract signing (6010), which always succeeds.
+func (s *Server) signContract(ctx context.Context, reqData []byte) (any, error) {
+	var req freelancers.SignContractRequest
+	if err := decode(reqData, &req); err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, cores.ErrApiNameEmpty
+	}
+	if req.IdCard == "" {
+		return nil, cores.ErrApiIdCardEmpty
+	}
+	if req.ProviderId == 0 {
+		return nil, cores.ErrApiServiceIdEmpty
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := contractKey(req.IdCard, req.ProviderId)
+	if contract, ok := s.contracts[key]; ok && contract.State == freelancers.SignStateSigned {
+		return nil, cores.ErrApiAlreadySigned
+	}
+
+	contract := &freelancers.SignContractResult{
+		Name:       req.Name,
+		CardNo:     req.CardNo,
+		IdCard:     req.IdCard,
+		Mobile:     req.Mobile,
+		State:      freelancers.SignStateSigned,
+		OtherParam: req.OtherParam,
+		ProviderId: req.ProviderId,
+	}
+	s.contracts[key] = contract
+	s.notifyAsync(req.NotifyUrl, cores.FunCodeSignContract, *contract)
+
+	return &freelancers.SignContractResponse{OtherParam: req.OtherParam}, nil
+}
+
+// signContractQuery handles the contract status query (6011).
+func (s *Server) signContractQuery(ctx context.Context, reqData []byte) (any, error) {
+	var req freelancers.SignQueryRequest
+	if err := decode(reqData, &req); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if contract, ok := s.contracts[contractKey(req.IdCard, req.ProviderId)]; ok {
+		return contract, nil
+	}
+	return &freelancers.SignContractResult{
+		Name:       req.Name,
+		IdCard:     req.IdCard,
+		Mobile:     req.Mobile,
+		State:      freelancers.SignStateNotFound,
+		ProviderId: req.ProviderId,
+	}, nil
+}
+
+// cancelContract handles the contract termination (6036) with one or all providers.
+func (s *Server) cancelContract(ctx context.Context, reqData []byte) (any, error) {
+	var req freelancers.CancelContractRequest
+	if err := decode(reqData, &req); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cancelled := 0
+	for _, contract := range s.contracts {
+		if contract.IdCard != req.IdcardNo || contract.State != freelancers.SignStateSigned {
+			continue
+		}
+		if req.ProviderId != 0 && contract.ProviderId != req.ProviderId {
+			continue
+		}
+		contract.State = freelancers.SignStateCancelled
+		cancelled++
+	}
+
+	if cancelled == 0 {
+		return &freelancers.CancelContractResult{
+			State:  freelancers.CancelContractStateFailed,
+			RetMsg: "未查询到签约记录",
+		}, nil
+	}
+	return &freelancers.CancelContractResult{State: freelancers.CancelContractStateSuccess}, nil
+}
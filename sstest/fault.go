@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sstest
+
+import (
+	"time"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// Fault is a scripted failure of a request to the gateway.
+type Fault struct {
+	Err       *cores.APIError // the error replied instead of the result, none if nil
+	Delay     time.Duration   // the delay before replying, beyond the client timeout to simulate a timeout
+	Processed bool            // whether the request is processed despite the fault, as with an ambiguous 6000
+}
+
+// FailWith returns a fault replying the error without processing the request.
+func FailWith(err *cores.APIError) Fault {
+	return Fault{Err: err}
+}
+
+// Unknown returns a fault replying ErrApiUnknown (6000) after processing the request,
+// the ambiguous outcome that must be resolved by querying.
+func Unknown() Fault {
+	return Fault{Err: cores.ErrApiUnknown, Processed: true}
+}
+
+// TooFrequent returns a fault replying ErrApiRequestTooFrequent (6042) without processing the request.
+func TooFrequent() Fault {
+	return Fault{Err: cores.ErrApiRequestTooFrequent}
+}
+
+// Timeout returns a fault delaying the reply by d, processing the request first.
+func Timeout(d time.Duration) Fault {
+	return Fault{Delay: d, Processed: true}
+}
+
+// Inject queues faults for the next requests of the function code, in order.
+// A nil function code queues faults for requests of any function code, used after the specific ones.
+func (s *Server) Inject(funCode *cores.FunCode, faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code := ""
+	if funCode != nil {
+		code = funCode.Code
+	}
+	s.faults[code] = append(s.faults[code], faults...)
+}
+
+// nextFault pops the next fault for the function code, the caller holding the lock.
+func (s *Server) nextFault(code string) (Fault, bool) {
+	for _, key := range []string{code, ""} {
+		if queue := s.faults[key]; len(queue) > 0 {
+			s.faults[key] = queue[1:]
+			return queue[0], true
+		}
+	}
+	return Fault{}, false
+}
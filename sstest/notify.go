@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sstest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// Notification is a notification pushed by the gateway.
+type Notification struct {
+	URL     string // the notify URL
+	FunCode string // the function code of the notification envelope
+	Data    string // the notification data in JSON before encryption
+	Acked   bool   // whether the receiver replied SUCCESS
+	Err     error  // the delivery error if any
+}
+
+// Notify pushes a signed notification of the data to the URL and reports whether it was acknowledged.
+// Use it to resend notifications as the platform does.
+func (s *Server) Notify(ctx context.Context, url string, funCode *cores.FunCode, data any) (bool, error) {
+	plain, err := json.Marshal(data)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal data: %w", err)
+	}
+
+	notification := Notification{URL: url, FunCode: funCode.Code, Data: string(plain)}
+	notification.Acked, notification.Err = s.notify(ctx, &notification)
+
+	s.mu.Lock()
+	s.notifications = append(s.notifications, notification)
+	s.mu.Unlock()
+
+	return notification.Acked, notification.Err
+}
+
+// Notifications waits for pending notifications and returns the ones pushed so far.
+func (s *Server) Notifications() []Notification {
+	s.notifyWG.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Notification(nil), s.notifications...)
+}
+
+// notifyAsync pushes the notification in the background if the URL is set.
+func (s *Server) notifyAsync(url string, funCode *cores.FunCode, data any) {
+	if url == "" {
+		return
+	}
+
+	s.notifyWG.Add(1)
+	go func() {
+		defer s.notifyWG.Done()
+		_, _ = s.Notify(context.Background(), url, funCode, data)
+	}()
+}
+
+// notify sends the notification and reports whether it was acknowledged.
+func (s *Server) notify(ctx context.Context, notification *Notification) (bool, error) {
+	resData, sign, err := s.sealPlain(notification.Data)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	s.lastReqId++
+	reqId := "N" + strconv.FormatInt(s.lastReqId, 10)
+	s.mu.Unlock()
+
+	body, err := json.Marshal(&cores.ResponseMessage{
+		ReqId:   reqId,
+		FunCode: notification.FunCode,
+		MerId:   DefaultMerchantID,
+		Version: "V1.0",
+		ResData: resData,
+		ResCode: cores.ErrApiSuccess.Code,
+		ResMsg:  cores.ErrApiSuccess.Message,
+		Sign:    sign,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notification.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json;charset=utf-8")
+
+	resp, err := s.notifier.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	reply, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	return resp.StatusCode == http.StatusOK && string(reply) == cores.NotificationAck, nil
+}
@@ -0,0 +1,227 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sstest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/payments"
+)
+
+// paymentBatch is a batch paid via the gateway.
+type paymentBatch struct {
+	funCode *cores.FunCode  // the payment function code, which decides the query function code
+	orders  []*paymentOrder // the orders in request order
+}
+
+// paymentOrder is an order paid via the gateway.
+type paymentOrder struct {
+	funCode   *cores.FunCode         // the payment function code, used for the notification
+	notifyUrl string                 // the notify URL of the order
+	result    payments.PaymentResult // the order result returned by queries and notifications
+}
+
+// FinalState returns the final state of a payment by the test environment rule on the
+// last digit of the amount in fen: 0 stays processing, odd fails, and even succeeds.
+func FinalState(amt int64) payments.PaymentState {
+	switch digit := amt % 10; {
+	case digit == 0:
+		return payments.PaymentStateProcessing
+	case digit%2 == 1:
+		return payments.PaymentStateFailed
+	default:
+		return payments.PaymentStateSuccess
+	}
+}
+
+// SetOrderState settles a processing order, e.g. one left processing by its amount, and pushes its notification.
+func (s *Server) SetOrderState(merOrderId string, state payments.PaymentState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, batch := range s.batches {
+		for _, order := range batch.orders {
+			if order.result.MerOrderId != merOrderId {
+				continue
+			}
+			if order.result.State != payments.PaymentStateProcessing {
+				return fmt.Errorf("order %s is not processing", merOrderId)
+			}
+			s.settle(order, state)
+			return nil
+		}
+	}
+	return fmt.Errorf("order %s not found", merOrderId)
+}
+
+// paymentHandler returns the handler of batch payment (6001) or one-click payment (6029).
+func (s *Server) paymentHandler(funCode *cores.FunCode) HandlerFunc {
+	return func(ctx context.Context, reqData []byte) (any, error) {
+		var req payments.PaymentRequest
+		if err := decode(reqData, &req); err != nil {
+			return nil, err
+		}
+		if req.MerBatchId == "" {
+			return nil, cores.ErrApiBatchNoEmpty
+		}
+		if req.ProviderId == 0 {
+			return nil, cores.ErrApiServiceIdEmpty
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.batches[req.MerBatchId]; ok {
+			return nil, cores.ErrApiBatchNoDuplicate
+		}
+
+		var total int64
+		merOrderIds := make(map[string]bool, len(req.PayItems))
+		for _, item := range req.PayItems {
+			if item.Amt <= 0 {
+				return nil, cores.ErrApiInvalidAmount
+			}
+			if merOrderIds[item.MerOrderId] || s.hasOrder(item.MerOrderId) {
+				return nil, cores.ErrApiOrderNoDuplicate
+			}
+			merOrderIds[item.MerOrderId] = true
+			total += item.Amt
+		}
+		if total > s.balance {
+			return nil, cores.ErrApiInsufficientBalance
+		}
+		s.balance -= total
+
+		batch := &paymentBatch{funCode: funCode}
+		resp := &payments.PaymentResponse{
+			SuccessNum: len(req.PayItems),
+			MerBatchId: req.MerBatchId,
+		}
+		now := time.Now().Format(time.DateTime)
+
+		for _, item := range req.PayItems {
+			s.lastOrderNo++
+			order := &paymentOrder{
+				funCode:   funCode,
+				notifyUrl: item.NotifyUrl,
+				result: payments.PaymentResult{
+					PaymentBaseResult: payments.PaymentBaseResult{
+						MerOrderId: item.MerOrderId,
+						State:      payments.PaymentStateProcessing,
+						Amt:        item.Amt,
+						UserDueAmt: item.Amt,
+						CreateTime: now,
+					},
+					OrderNo: s.lastOrderNo,
+				},
+			}
+			batch.orders = append(batch.orders, order)
+
+			resp.PayResultList = append(resp.PayResultList, payments.PaymentExecuteResult{
+				PaymentBaseResult: order.result.PaymentBaseResult,
+				OrderNo:           strconv.FormatInt(order.result.OrderNo, 10),
+			})
+		}
+		s.batches[req.MerBatchId] = batch
+
+		for _, order := range batch.orders {
+			if state := FinalState(order.result.Amt); state != payments.PaymentStateProcessing {
+				s.settle(order, state)
+			}
+		}
+
+		return resp, nil
+	}
+}
+
+// paymentQueryHandler returns the handler of the query (6002 or 6030) of batches paid via the function code.
+func (s *Server) paymentQueryHandler(funCode *cores.FunCode) HandlerFunc {
+	return func(ctx context.Context, reqData []byte) (any, error) {
+		var req payments.PaymentQueryRequest
+		if err := decode(reqData, &req); err != nil {
+			return nil, err
+		}
+		if req.MerBatchId == "" {
+			return nil, cores.ErrApiBatchNoEmpty
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		batch, ok := s.batches[req.MerBatchId]
+		if !ok || batch.funCode != funCode {
+			return nil, cores.ErrApiBatchNoNotFound
+		}
+
+		resp := &payments.PaymentBatchResult{MerId: DefaultMerchantID, MerBatchId: req.MerBatchId}
+		for _, order := range batch.orders {
+			if matchQueryItems(order, req.QueryItems) {
+				resp.QueryItems = append(resp.QueryItems, order.result)
+			}
+		}
+		return resp, nil
+	}
+}
+
+// matchQueryItems reports whether the order is queried, all orders if no items are given.
+func matchQueryItems(order *paymentOrder, items []payments.PaymentQueryItem) bool {
+	if len(items) == 0 {
+		return true
+	}
+	orderNo := strconv.FormatInt(order.result.OrderNo, 10)
+	for _, item := range items {
+		if item.MerOrderId == order.result.MerOrderId || item.OrderNo == orderNo {
+			return true
+		}
+	}
+	return false
+}
+
+// hasOrder reports whether the merchant order ID has been paid, the caller holding the lock.
+func (s *Server) hasOrder(merOrderId string) bool {
+	for _, batch := range s.batches {
+		for _, order := range batch.orders {
+			if order.result.MerOrderId == merOrderId {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// settle sets the order state, refunds failed orders and pushes the notification,
+// the caller holding the lock.
+func (s *Server) settle(order *paymentOrder, state payments.PaymentState) {
+	order.result.State = state
+	order.result.EndTime = time.Now().Format(time.DateTime)
+
+	switch state {
+	case payments.PaymentStateSuccess:
+		order.result.ResCode = cores.ErrApiSuccess.Code
+		order.result.ResMsg = "付款成功"
+	case payments.PaymentStateFailed, payments.PaymentStateCancelled:
+		order.result.ResMsg = "付款失败"
+		s.balance += order.result.Amt
+	}
+
+	s.notifyAsync(order.notifyUrl, order.funCode, order.result)
+}
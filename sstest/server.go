@@ -0,0 +1,294 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sstest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/freelancers"
+)
+
+const (
+	DefaultMerchantID = "M0001"     // the merchant ID of the fake gateway
+	DefaultDesKey     = "12345678"  // the DES key of the fake gateway
+	DefaultBalance    = 100_000_000 // the initial account balance in fen
+)
+
+// HandlerFunc handles the decrypted request data of a function code.
+// It returns the response data to encrypt, or an error replied as the response code,
+// using the code of a *cores.APIError and ErrApiUnknown otherwise.
+type HandlerFunc func(ctx context.Context, reqData []byte) (any, error)
+
+// Server is a fake ServiceShare gateway for offline integration tests.
+//
+// It speaks the real envelope with generated RSA key pairs and DES-ECB, and implements
+// balance query (6003), contract signing, query and termination (6010/6011/6036), and
+// batch and one-click payment with their queries (6001/6002/6029/6030) on in-memory state.
+// Other function codes reply ErrApiApiNotSupported unless registered with Handle.
+//
+// Payment amounts follow the test environment rule by the last digit in fen:
+// 0 stays processing, odd fails, and even succeeds.
+// Final results are pushed as signed notifications to each item's notifyUrl.
+type Server struct {
+	URL string // the base URL of the gateway
+
+	httpServer  *httptest.Server
+	merchantKey *rsa.PrivateKey // the merchant key, whose private key is handed to the client
+	platformKey *rsa.PrivateKey // the platform key signing responses and notifications
+	notifier    *http.Client
+	notifyWG    sync.WaitGroup
+
+	mu            sync.Mutex
+	handlers      map[string]HandlerFunc
+	faults        map[string][]Fault
+	requests      map[string]int
+	balance       int64
+	contracts     map[string]*freelancers.SignContractResult
+	batches       map[string]*paymentBatch
+	lastOrderNo   int64
+	lastReqId     int64
+	notifications []Notification
+}
+
+// NewServer starts a fake gateway, which should be closed when finished.
+func NewServer() *Server {
+	s := &Server{
+		merchantKey: generateKey(),
+		platformKey: generateKey(),
+		notifier:    &http.Client{Timeout: 10 * time.Second},
+		handlers:    make(map[string]HandlerFunc),
+		faults:      make(map[string][]Fault),
+		requests:    make(map[string]int),
+		balance:     DefaultBalance,
+		contracts:   make(map[string]*freelancers.SignContractResult),
+		batches:     make(map[string]*paymentBatch),
+		lastOrderNo: 1_000_000,
+	}
+
+	s.handlers[cores.FunCodeBalanceQuery.Code] = s.balanceQuery
+	s.handlers[cores.FunCodeSignContract.Code] = s.signContract
+	s.handlers[cores.FunCodeSignContractQuery.Code] = s.signContractQuery
+	s.handlers[cores.FunCodeCancelContract.Code] = s.cancelContract
+	s.handlers[cores.FunCodePayment.Code] = s.paymentHandler(cores.FunCodePayment)
+	s.handlers[cores.FunCodePaymentQuery.Code] = s.paymentQueryHandler(cores.FunCodePayment)
+	s.handlers[cores.FunCodeOneClickPayment.Code] = s.paymentHandler(cores.FunCodeOneClickPayment)
+	s.handlers[cores.FunCodeOneClickQuery.Code] = s.paymentQueryHandler(cores.FunCodeOneClickPayment)
+
+	s.httpServer = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.httpServer.URL
+	return s
+}
+
+// generateKey generates an RSA key, panicking on failure like httptest does.
+func generateKey() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(fmt.Sprintf("sstest: failed to generate key: %v", err))
+	}
+	return key
+}
+
+// Close waits for pending notifications and shuts down the gateway.
+func (s *Server) Close() {
+	s.notifyWG.Wait()
+	s.httpServer.Close()
+}
+
+// Config returns a new client configuration for the gateway.
+func (s *Server) Config() *cores.Config {
+	privateDER, err := x509.MarshalPKCS8PrivateKey(s.merchantKey)
+	if err != nil {
+		panic(fmt.Sprintf("sstest: failed to marshal key: %v", err))
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&s.platformKey.PublicKey)
+	if err != nil {
+		panic(fmt.Sprintf("sstest: failed to marshal key: %v", err))
+	}
+
+	return cores.NewConfig(
+		s.URL,
+		DefaultMerchantID,
+		DefaultDesKey,
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+		0,
+	)
+}
+
+// Handle registers the handler of a function code, replacing the built-in one if any.
+func (s *Server) Handle(funCode *cores.FunCode, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[funCode.Code] = handler
+}
+
+// SetBalance sets the account balance in fen.
+func (s *Server) SetBalance(balance int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.balance = balance
+}
+
+// Requests returns the count of requests received for the function code, including faulted ones.
+func (s *Server) Requests(funCode *cores.FunCode) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[funCode.Code]
+}
+
+// serveHTTP verifies and decrypts the request envelope and replies the handler result.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	var reqMsg cores.RequestMessage
+	if err := json.Unmarshal(body, &reqMsg); err != nil {
+		http.Error(w, "invalid request message", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests[reqMsg.FunCode]++
+	fault, faulted := s.nextFault(reqMsg.FunCode)
+	handler := s.handlers[reqMsg.FunCode]
+	s.mu.Unlock()
+
+	// A fault reply skips processing unless the fault says the request was processed
+	skip := faulted && fault.Err != nil && !fault.Processed
+	processFirst := faulted && fault.Processed
+
+	var resData any
+	if processFirst {
+		resData, err = s.handle(r.Context(), &reqMsg, handler, false)
+	}
+
+	if faulted && fault.Delay > 0 {
+		select {
+		case <-time.After(fault.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if !processFirst {
+		resData, err = s.handle(r.Context(), &reqMsg, handler, skip)
+	}
+	if faulted && fault.Err != nil {
+		resData, err = nil, fault.Err
+	}
+
+	s.reply(w, &reqMsg, resData, err)
+}
+
+// handle verifies and decrypts the request and runs the handler unless skipped.
+func (s *Server) handle(ctx context.Context, reqMsg *cores.RequestMessage, handler HandlerFunc, skip bool) (any, error) {
+	if reqMsg.MerId != DefaultMerchantID {
+		return nil, cores.ErrApiCustomerNotFound
+	}
+	if err := cores.Verify(reqMsg.ReqData, reqMsg.Sign, &s.merchantKey.PublicKey); err != nil {
+		return nil, cores.ErrApiSignVerifyFailed
+	}
+	reqData, err := cores.DecryptDES(reqMsg.ReqData, DefaultDesKey)
+	if err != nil {
+		return nil, cores.ErrApiDecryptFailed
+	}
+
+	if skip {
+		return nil, nil
+	}
+	if handler == nil {
+		return nil, cores.ErrApiApiNotSupported
+	}
+	return handler(ctx, []byte(reqData))
+}
+
+// reply writes the response message of the handler result.
+func (s *Server) reply(w http.ResponseWriter, reqMsg *cores.RequestMessage, resData any, err error) {
+	resMsg := &cores.ResponseMessage{
+		ReqId:   reqMsg.ReqId,
+		FunCode: reqMsg.FunCode,
+		MerId:   reqMsg.MerId,
+		Version: reqMsg.Version,
+		ResCode: cores.ErrApiSuccess.Code,
+		ResMsg:  cores.ErrApiSuccess.Message,
+	}
+
+	if err == nil && resData != nil {
+		resMsg.ResData, resMsg.Sign, err = s.seal(resData)
+	}
+
+	if err != nil {
+		var apiErr *cores.APIError
+		if !errors.As(err, &apiErr) {
+			apiErr = cores.NewAPIError(cores.ErrApiUnknown.Code, err.Error())
+		}
+		resMsg.ResCode = apiErr.Code
+		resMsg.ResMsg = apiErr.Message
+	}
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	_ = json.NewEncoder(w).Encode(resMsg)
+}
+
+// seal marshals, encrypts and signs the data with the platform key.
+func (s *Server) seal(data any) (string, string, error) {
+	plain, err := json.Marshal(data)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal data: %w", err)
+	}
+	return s.sealPlain(string(plain))
+}
+
+// sealPlain encrypts and signs the JSON data with the platform key.
+func (s *Server) sealPlain(plain string) (string, string, error) {
+	encrypted, err := cores.EncryptDES(plain, DefaultDesKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	sign, err := cores.Sign(encrypted, s.platformKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return encrypted, sign, nil
+}
+
+// decode unmarshals the request data.
+func decode(reqData []byte, v any) error {
+	if err := json.Unmarshal(reqData, v); err != nil {
+		return cores.NewAPIError(cores.ErrApiParamError.Code, err.Error())
+	}
+	return nil
+}
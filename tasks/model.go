@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/cores"
+)
+
+// TaskStatus represents the task status.
+type TaskStatus string
+
+const (
+	TaskStatusToRelease            TaskStatus = "TO_RELEASE"             // the task is waiting to be released
+	TaskStatusPlatformReviewWait   TaskStatus = "PLATFORM_REVIEW_WAIT"   // the task is waiting for platform review
+	TaskStatusPlatformReviewRefuse TaskStatus = "PLATFORM_REVIEW_REFUSE" // the task was refused by the platform
+	TaskStatusLevyReviewWait       TaskStatus = "LEVY_REVIEW_WAIT"       // the task is waiting for service provider review
+	TaskStatusLevyReviewRefuse     TaskStatus = "LEVY_REVIEW_REFUSE"     // the task was refused by the service provider
+	TaskStatusToStart              TaskStatus = "TO_START"               // the task is waiting to start
+	TaskStatusConduct              TaskStatus = "TASK_CONDUCT"           // the task is in progress
+	TaskStatusShut                 TaskStatus = "TASK_SHUT"              // the task is closed
+	TaskStatusEnd                  TaskStatus = "TASK_END"               // the task is completed
+)
+
+// IsActive reports whether payments can be made under the task.
+func (s TaskStatus) IsActive() bool {
+	return s == TaskStatusConduct
+}
+
+// Task represents a task of the merchant, used as the reason of payments.
+type Task struct {
+	TaskId     int64      `json:"taskId"`     // the task ID
+	TaskName   string     `json:"taskName"`   // the task name
+	TaskStatus TaskStatus `json:"taskStatus"` // the task status
+	StartTime  string     `json:"startTime"`  // the task start date (format: yyyy-MM-dd)
+	EndTime    string     `json:"endTime"`    // the task end date (format: yyyy-MM-dd)
+}
+
+// Contains reports whether the date of at in China Standard Time is within the task's start and end dates.
+// An empty start or end date leaves that side unbounded.
+func (t *Task) Contains(at time.Time) (bool, error) {
+	date := at.In(cores.Location).Format(time.DateOnly)
+
+	if t.StartTime != "" {
+		start, err := time.ParseInLocation(time.DateOnly, t.StartTime, cores.Location)
+		if err != nil {
+			return false, fmt.Errorf("invalid startTime of task %d: %w", t.TaskId, err)
+		}
+		if date < start.Format(time.DateOnly) {
+			return false, nil
+		}
+	}
+
+	if t.EndTime != "" {
+		end, err := time.ParseInLocation(time.DateOnly, t.EndTime, cores.Location)
+		if err != nil {
+			return false, fmt.Errorf("invalid endTime of task %d: %w", t.TaskId, err)
+		}
+		if date > end.Format(time.DateOnly) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,181 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vogo/vservicesharesdk/cores"
+	"github.com/vogo/vservicesharesdk/payments"
+)
+
+// DefaultTaskCacheTTL is the default time the task list is cached.
+const DefaultTaskCacheTTL = 10 * time.Minute
+
+// TaskCache caches the task list and checks tasks before payments are submitted.
+// It is safe for concurrent use. The task list is loaded without holding the cache lock,
+// and concurrent callers finding it expired share a single load.
+type TaskCache struct {
+	service *Service
+	ttl     time.Duration
+
+	mu       sync.Mutex
+	tasks    map[int64]Task
+	loadedAt time.Time
+	loading  *taskLoad // the load in flight, nil if none
+}
+
+// taskLoad is a load of the task list shared by concurrent callers.
+type taskLoad struct {
+	done chan struct{} // closed when the load finishes
+	err  error         // the error of the load, set before done is closed
+}
+
+// NewTaskCache creates a new task cache reloading the task list after ttl,
+// DefaultTaskCacheTTL if ttl is not positive.
+func NewTaskCache(service *Service, ttl time.Duration) *TaskCache {
+	if ttl <= 0 {
+		ttl = DefaultTaskCacheTTL
+	}
+	return &TaskCache{
+		service: service,
+		ttl:     ttl,
+	}
+}
+
+// Refresh reloads the task list.
+func (c *TaskCache) Refresh() error {
+	return c.RefreshContext(context.Background())
+}
+
+// RefreshContext is like Refresh but carries the context for cancellation and deadlines.
+func (c *TaskCache) RefreshContext(ctx context.Context) error {
+	return c.load(ctx)
+}
+
+// load reloads the task list, or waits for the load in flight.
+func (c *TaskCache) load(ctx context.Context) error {
+	c.mu.Lock()
+	if call := c.loading; call != nil {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.err
+		case <-ctx.Done():
+			return fmt.Errorf("failed to list tasks: %w", ctx.Err())
+		}
+	}
+	call := &taskLoad{done: make(chan struct{})}
+	c.loading = call
+	c.mu.Unlock()
+
+	list, err := c.service.ListTasksContext(ctx)
+
+	c.mu.Lock()
+	if err != nil {
+		call.err = fmt.Errorf("failed to list tasks: %w", err)
+	} else {
+		tasks := make(map[int64]Task, len(list))
+		for _, task := range list {
+			tasks[task.TaskId] = task
+		}
+		c.tasks = tasks
+		c.loadedAt = time.Now()
+	}
+	c.loading = nil
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.err
+}
+
+// Get returns the task, reloading the task list if it has expired.
+// The returned error wraps cores.ErrApiTaskNotFound if the task does not exist.
+func (c *TaskCache) Get(taskId int64) (*Task, error) {
+	return c.GetContext(context.Background(), taskId)
+}
+
+// GetContext is like Get but carries the context for cancellation and deadlines.
+func (c *TaskCache) GetContext(ctx context.Context, taskId int64) (*Task, error) {
+	c.mu.Lock()
+	expired := c.tasks == nil || time.Since(c.loadedAt) > c.ttl
+	c.mu.Unlock()
+
+	if expired {
+		if err := c.load(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	c.mu.Lock()
+	task, ok := c.tasks[taskId]
+	c.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: task %d", cores.ErrApiTaskNotFound, taskId)
+	}
+	return &task, nil
+}
+
+// CheckTask checks the task is in progress and the date of at is within its start and end dates.
+//
+// The returned error wraps cores.ErrApiTaskNotFound (6041) if the task does not exist,
+// or cores.ErrApiTaskStatusError (6050) if the task is not active or out of its date range.
+func (c *TaskCache) CheckTask(taskId int64, at time.Time) error {
+	return c.CheckTaskContext(context.Background(), taskId, at)
+}
+
+// CheckTaskContext is like CheckTask but carries the context for cancellation and deadlines.
+func (c *TaskCache) CheckTaskContext(ctx context.Context, taskId int64, at time.Time) error {
+	task, err := c.GetContext(ctx, taskId)
+	if err != nil {
+		return err
+	}
+
+	if !task.TaskStatus.IsActive() {
+		return fmt.Errorf("%w: task %d is %s", cores.ErrApiTaskStatusError, taskId, task.TaskStatus)
+	}
+
+	ok, err := task.Contains(at)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: task %d runs from %s to %s", cores.ErrApiTaskStatusError, taskId, task.StartTime, task.EndTime)
+	}
+
+	return nil
+}
+
+// CheckPayment checks the task of the payment request can be used now.
+func (c *TaskCache) CheckPayment(req *payments.PaymentRequest) error {
+	return c.CheckPaymentContext(context.Background(), req)
+}
+
+// CheckPaymentContext is like CheckPayment but carries the context for cancellation and deadlines.
+func (c *TaskCache) CheckPaymentContext(ctx context.Context, req *payments.PaymentRequest) error {
+	if req == nil {
+		return fmt.Errorf("request cannot be nil")
+	}
+	if req.TaskId == 0 {
+		return fmt.Errorf("taskId is required")
+	}
+	return c.CheckTaskContext(ctx, req.TaskId, time.Now())
+}
@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wechat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/vogo/vservicesharesdk/payments"
+)
+
+// BusinessTypeRequestMerchantTransfer is the businessType of the WeChat Open SDK request
+// used by Android and iOS apps to launch the user confirmation page.
+const BusinessTypeRequestMerchantTransfer = "requestMerchantTransfer"
+
+// TransferParams represents the parameters for launching the WeChat user confirmation page.
+type TransferParams struct {
+	MchId   string `json:"mchId"`   // the WeChat merchant ID returned by the payment
+	AppId   string `json:"appId"`   // the app ID of the mobile app, mini-program or official account
+	Package string `json:"package"` // the package information returned by the payment
+}
+
+// NewTransferParams creates the launch parameters from a payment result and the app ID.
+//
+// The result must come from a WeChat payment in the small-change new mode, which carries
+// PackageInfo and MchId. The app ID is optional for Android/iOS apps but required for JSAPI.
+func NewTransferParams(result *payments.PaymentExecuteResult, appId string) (*TransferParams, error) {
+	if result == nil {
+		return nil, fmt.Errorf("payment result cannot be nil")
+	}
+	if result.PackageInfo == "" {
+		return nil, fmt.Errorf("packageInfo is empty, payment %s is not pending WeChat user confirmation", result.MerOrderId)
+	}
+	if result.MchId == "" {
+		return nil, fmt.Errorf("mchId is empty for payment %s", result.MerOrderId)
+	}
+
+	return &TransferParams{
+		MchId:   result.MchId,
+		AppId:   appId,
+		Package: result.PackageInfo,
+	}, nil
+}
+
+// AppQuery returns the URL-encoded query of the WeChat Open SDK request used by Android
+// and iOS apps together with BusinessTypeRequestMerchantTransfer, for example:
+//
+//	mchId=1230000000&appId=wx8888888888888888&package=affffddafdfafddffda%3D%3D
+func (p *TransferParams) AppQuery() string {
+	var b strings.Builder
+	b.WriteString("mchId=")
+	b.WriteString(url.QueryEscape(p.MchId))
+	if p.AppId != "" {
+		b.WriteString("&appId=")
+		b.WriteString(url.QueryEscape(p.AppId))
+	}
+	b.WriteString("&package=")
+	b.WriteString(url.QueryEscape(p.Package))
+	return b.String()
+}
+
+// JSAPIParams returns the JSON object passed to wx.requestMerchantTransfer in mini-programs
+// or to WeixinJSBridge.invoke('requestMerchantTransfer', ...) in official account pages.
+func (p *TransferParams) JSAPIParams() ([]byte, error) {
+	if p.AppId == "" {
+		return nil, fmt.Errorf("appId is required for JSAPI")
+	}
+
+	// Keep the package value verbatim instead of escaping HTML characters
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(p); err != nil {
+		return nil, fmt.Errorf("failed to marshal JSAPI params: %w", err)
+	}
+
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}